@@ -0,0 +1,77 @@
+package core
+
+import (
+	"sort"
+	"sync"
+)
+
+// appVersionCache remembers app versions observed at specific heights.
+// App versions only ever increase over the lifetime of a chain, so if two observed heights report the same
+// version, every height in between must be on that version as well. This allows the cache to answer for whole
+// height ranges after only a couple of fetches.
+type appVersionCache struct {
+	lk  sync.RWMutex
+	obs []appVersionObservation // sorted by height
+}
+
+type appVersionObservation struct {
+	height  int64
+	version uint64
+}
+
+func newAppVersionCache() *appVersionCache {
+	return &appVersionCache{}
+}
+
+// get reports the app version for the given height if it can be derived from the observations.
+func (c *appVersionCache) get(height int64) (uint64, bool) {
+	c.lk.RLock()
+	defer c.lk.RUnlock()
+
+	i := c.search(height)
+	if i < len(c.obs) && c.obs[i].height == height {
+		return c.obs[i].version, true
+	}
+	// height is not observed, so it must be enclosed by two observations with the same version
+	if i == 0 || i == len(c.obs) {
+		return 0, false
+	}
+	if lo, hi := c.obs[i-1], c.obs[i]; lo.version == hi.version {
+		return lo.version, true
+	}
+	return 0, false
+}
+
+// put records the app version observed at the given height.
+func (c *appVersionCache) put(height int64, version uint64) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	i := c.search(height)
+	if i < len(c.obs) && c.obs[i].height == height {
+		return
+	}
+	c.obs = append(c.obs, appVersionObservation{})
+	copy(c.obs[i+1:], c.obs[i:])
+	c.obs[i] = appVersionObservation{height: height, version: version}
+	c.compact()
+}
+
+// compact drops observations enclosed by neighbours with the same version,
+// as they carry no extra information and only the range boundaries matter.
+func (c *appVersionCache) compact() {
+	out := make([]appVersionObservation, 0, len(c.obs))
+	for i, o := range c.obs {
+		if i > 0 && i < len(c.obs)-1 && c.obs[i-1].version == o.version && c.obs[i+1].version == o.version {
+			continue
+		}
+		out = append(out, o)
+	}
+	c.obs = out
+}
+
+func (c *appVersionCache) search(height int64) int {
+	return sort.Search(len(c.obs), func(i int) bool {
+		return c.obs[i].height >= height
+	})
+}
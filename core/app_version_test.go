@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppVersionCache(t *testing.T) {
+	cache := newAppVersionCache()
+
+	_, ok := cache.get(1)
+	assert.False(t, ok)
+
+	cache.put(10, 1)
+	cache.put(100, 1)
+	// version transition happens somewhere in between 100 and 200
+	cache.put(200, 2)
+	cache.put(300, 2)
+
+	var tests = []struct {
+		height  int64
+		version uint64
+		ok      bool
+	}{
+		{height: 9, ok: false},
+		{height: 10, version: 1, ok: true},
+		{height: 50, version: 1, ok: true},
+		{height: 100, version: 1, ok: true},
+		{height: 150, ok: false},
+		{height: 200, version: 2, ok: true},
+		{height: 250, version: 2, ok: true},
+		{height: 301, ok: false},
+	}
+
+	for _, tt := range tests {
+		version, ok := cache.get(tt.height)
+		assert.Equal(t, tt.ok, ok, tt.height)
+		assert.Equal(t, tt.version, version, tt.height)
+	}
+
+	// pinpointing the transition keeps only range boundaries around
+	cache.put(150, 2)
+	cache.put(120, 1)
+	assert.Len(t, cache.obs, 4)
+
+	version, ok := cache.get(130)
+	assert.False(t, ok)
+	assert.Zero(t, version)
+
+	version, ok = cache.get(110)
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, version)
+}
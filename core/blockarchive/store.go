@@ -0,0 +1,67 @@
+// Package blockarchive keeps Core blocks locally, so that historical blocks can be served without asking Core.
+package blockarchive
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ipfs/go-datastore"
+
+	tmproto "github.com/celestiaorg/celestia-core/proto/tendermint/types"
+	"github.com/celestiaorg/celestia-core/types"
+)
+
+// ErrNotFound is returned when the Store does not have a `Block` at the requested height.
+var ErrNotFound = errors.New("blockarchive: block not found")
+
+// Store is a local archive of blocks.
+type Store interface {
+	// Get returns the `Block` at the given height or ErrNotFound.
+	Get(height int64) (*types.Block, error)
+	// Put archives the given `Block`.
+	Put(*types.Block) error
+}
+
+// DatastoreStore is a Store keeping blocks in a Datastore, e.g. the node's on-disk Badger.
+type DatastoreStore struct {
+	ds datastore.Datastore
+}
+
+// NewDatastoreStore creates a new DatastoreStore over the given Datastore.
+func NewDatastoreStore(ds datastore.Datastore) *DatastoreStore {
+	return &DatastoreStore{ds: ds}
+}
+
+func (s *DatastoreStore) Get(height int64) (*types.Block, error) {
+	bin, err := s.ds.Get(heightKey(height))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	var pb tmproto.Block
+	err = pb.Unmarshal(bin)
+	if err != nil {
+		return nil, err
+	}
+	return types.BlockFromProto(&pb)
+}
+
+func (s *DatastoreStore) Put(b *types.Block) error {
+	pb, err := b.ToProto()
+	if err != nil {
+		return err
+	}
+
+	bin, err := pb.Marshal()
+	if err != nil {
+		return err
+	}
+	return s.ds.Put(heightKey(b.Height), bin)
+}
+
+func heightKey(height int64) datastore.Key {
+	return datastore.NewKey(strconv.FormatInt(height, 10))
+}
@@ -0,0 +1,28 @@
+package blockarchive
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-core/types"
+)
+
+func TestDatastoreStore(t *testing.T) {
+	store := NewDatastoreStore(dssync.MutexWrap(datastore.NewMapDatastore()))
+
+	_, err := store.Get(1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	in := types.MakeBlock(1, []types.Tx{[]byte("tx1"), []byte("tx2")}, nil, nil, types.Messages{}, &types.Commit{})
+	in.ProposerAddress = make([]byte, 20)
+	require.NoError(t, store.Put(in))
+
+	out, err := store.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, in.Hash(), out.Hash())
+	assert.Equal(t, in.Data.Txs, out.Data.Txs)
+}
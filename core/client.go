@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 
 	corenode "github.com/celestiaorg/celestia-core/node"
@@ -9,15 +10,25 @@ import (
 	"github.com/celestiaorg/celestia-core/rpc/client/local"
 )
 
-// Client is an alias to Core Client.
-type Client = client.Client
+// Client is a Core Client extended with a few helpers.
+type Client interface {
+	client.Client
+
+	// Ping checks whether the Core node is reachable and responsive.
+	Ping(ctx context.Context) error
+}
 
 // NewRemote creates a new Client that communicates with a remote Core endpoint over HTTP.
 func NewRemote(protocol, remoteAddr string) (Client, error) {
-	return http.New(
+	remote, err := http.New(
 		fmt.Sprintf("%s://%s", protocol, remoteAddr),
 		"/websocket",
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteWrapper{remote}, nil
 }
 
 // NewEmbedded returns a new Client from an embedded Core node process.
@@ -35,6 +46,15 @@ func NewEmbeddedFromNode(node *corenode.Node) Client {
 	return &embeddedWrapper{local.New(node), node}
 }
 
+// remoteWrapper is a small wrapper around HTTP Client which extends it with helpers.
+type remoteWrapper struct {
+	*http.HTTP
+}
+
+func (r *remoteWrapper) Ping(ctx context.Context) error {
+	return ping(ctx, r)
+}
+
 // embeddedWrapper is a small wrapper around local Client which ensures the embedded Core node
 // can be started/stopped.
 type embeddedWrapper struct {
@@ -49,3 +69,13 @@ func (e *embeddedWrapper) Start() error {
 func (e *embeddedWrapper) Stop() error {
 	return e.node.Stop()
 }
+
+func (e *embeddedWrapper) Ping(ctx context.Context) error {
+	return ping(ctx, e)
+}
+
+// ping does the cheapest request possible to Core, discarding the response.
+func ping(ctx context.Context, client client.StatusClient) error {
+	_, err := client.Status(ctx)
+	return err
+}
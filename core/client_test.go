@@ -2,9 +2,13 @@ package core
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
 )
 
 func TestEmbeddedClientLifecycle(t *testing.T) {
@@ -25,6 +29,16 @@ func TestEmbeddedClient_Status(t *testing.T) {
 	require.NoError(t, client.Stop())
 }
 
+func TestEmbeddedClient_Ping(t *testing.T) {
+	client := MockEmbeddedClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, client.Ping(ctx))
+	require.NoError(t, client.Stop())
+}
+
 func TestEmbeddedClient_StartBlockSubscription_And_GetBlock(t *testing.T) {
 	client := MockEmbeddedClient()
 
@@ -75,6 +89,28 @@ func TestRemoteClient_Status(t *testing.T) {
 	require.NoError(t, remote.Stop())
 }
 
+func TestRemoteClient_Ping(t *testing.T) {
+	remote, client, err := StartRemoteClient()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, client.Start())
+	require.NoError(t, client.Ping(ctx))
+
+	require.NoError(t, client.Stop())
+	require.NoError(t, remote.Stop())
+}
+
+func TestPing_Error(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).Return(nil, errUnavailable)
+	err := ping(context.Background(), client)
+	require.ErrorIs(t, err, errUnavailable)
+}
+
 func TestRemoteClient_StartBlockSubscription_And_GetBlock(t *testing.T) {
 	remote, client, err := StartRemoteClient()
 	require.NoError(t, err)
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-core/types"
+)
+
+var (
+	// ErrClientNotRunning is returned when a request needs a started Client.
+	ErrClientNotRunning = errors.New("core: client not running")
+	// ErrSubscriptionExists is returned on attempt to subscribe to new block events twice.
+	ErrSubscriptionExists = errors.New("core: new block event channel exists")
+	// ErrNoSubscription is returned on attempt to unsubscribe from new block events without subscribing first.
+	ErrNoSubscription = errors.New("core: no new block event channel found")
+	// ErrUnknownBlockHash is returned when Core does not have a `Block` with the requested hash.
+	ErrUnknownBlockHash = errors.New("core: unknown block hash")
+	// ErrSizeUnavailable is returned when Core does not report the size of the requested `Block`.
+	ErrSizeUnavailable = errors.New("core: block size unavailable")
+)
+
+// BlockNotFoundError is returned when Core does not have a `Block` at the Height.
+type BlockNotFoundError struct {
+	Height int64
+}
+
+func (e *BlockNotFoundError) Error() string {
+	return fmt.Sprintf("core: block not found at height %d", e.Height)
+}
+
+// CommitNotFoundError is returned when Core does not have a `Commit` at the Height.
+type CommitNotFoundError struct {
+	Height int64
+}
+
+func (e *CommitNotFoundError) Error() string {
+	return fmt.Sprintf("core: commit not found at height %d", e.Height)
+}
+
+// ValidatorSetNotFoundError is returned when Core does not have a `ValidatorSet` at the Height.
+type ValidatorSetNotFoundError struct {
+	Height int64
+}
+
+func (e *ValidatorSetNotFoundError) Error() string {
+	return fmt.Sprintf("core: validator set not found at height %d", e.Height)
+}
+
+// ValidatorNotFoundError is returned when the requested validator is not in the validator set.
+type ValidatorNotFoundError struct {
+	Height  int64
+	Address types.Address
+}
+
+func (e *ValidatorNotFoundError) Error() string {
+	return fmt.Sprintf("core: validator %s not found at height %d", e.Address, e.Height)
+}
+
+// TxNotFoundError is returned when the `Block` at the Height does not contain a transaction with the Hash.
+type TxNotFoundError struct {
+	Height int64
+	Hash   []byte
+}
+
+func (e *TxNotFoundError) Error() string {
+	return fmt.Sprintf("core: tx %X not found at height %d", e.Hash, e.Height)
+}
+
+// StreamError is returned when an operation over the Core event stream fails.
+type StreamError struct {
+	Op  string
+	Err error
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("core: stream %s: %s", e.Op, e.Err)
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether the error signals that Core does not have the requested data (yet).
+func IsNotFound(err error) bool {
+	var (
+		blockErr  *BlockNotFoundError
+		commitErr *CommitNotFoundError
+		valSetErr *ValidatorSetNotFoundError
+	)
+	return errors.As(err, &blockErr) || errors.As(err, &commitErr) || errors.As(err, &valSetErr)
+}
+
+// IsStreamError reports whether the error happened while operating the Core event stream.
+func IsStreamError(err error) bool {
+	var streamErr *StreamError
+	return errors.As(err, &streamErr)
+}
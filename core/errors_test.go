@@ -0,0 +1,39 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	errTransport := errors.New("connection refused")
+
+	var tests = []struct {
+		name     string
+		err      error
+		notFound bool
+		stream   bool
+	}{
+		{name: "block", err: &BlockNotFoundError{Height: 1}, notFound: true},
+		{name: "commit", err: &CommitNotFoundError{Height: 1}, notFound: true},
+		{name: "validator set", err: &ValidatorSetNotFoundError{Height: 1}, notFound: true},
+		{name: "tx", err: &TxNotFoundError{Height: 1, Hash: []byte{1}}},
+		{name: "stream", err: &StreamError{Op: "subscribe", Err: errTransport}, stream: true},
+		{name: "other", err: errTransport},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			assert.Equal(t, tt.notFound, IsNotFound(wrapped))
+			assert.Equal(t, tt.stream, IsStreamError(wrapped))
+			assert.ErrorIs(t, wrapped, tt.err)
+		})
+	}
+
+	streamErr := &StreamError{Op: "unsubscribe", Err: errTransport}
+	assert.ErrorIs(t, streamErr, errTransport)
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"sync"
+)
+
+// eventBufferSize is the amount of Events buffered for each subscriber.
+// Events are dropped for subscribers that cannot keep up.
+const eventBufferSize = 32
+
+// EventType identifies a kind of Event emitted by the BlockFetcher.
+type EventType uint8
+
+const (
+	// BeforeFetchEvent is emitted right before a block is requested from Core.
+	BeforeFetchEvent EventType = iota + 1
+	// AfterFetchEvent is emitted once a block request to Core is finished.
+	AfterFetchEvent
+)
+
+// Event is a notification emitted by the BlockFetcher.
+type Event interface {
+	Type() EventType
+}
+
+// BeforeFetch notifies that a block at the Height is about to be fetched.
+// Zero Height stands for the latest block.
+type BeforeFetch struct {
+	Height int64
+}
+
+func (BeforeFetch) Type() EventType {
+	return BeforeFetchEvent
+}
+
+// AfterFetch notifies that fetching of a block at the Height has finished.
+// Zero Height stands for the latest block.
+type AfterFetch struct {
+	Height     int64
+	DurationMs int64
+	Error      error
+}
+
+func (AfterFetch) Type() EventType {
+	return AfterFetchEvent
+}
+
+// EventBus delivers BlockFetcher Events to subscribed handlers.
+// Handlers are executed asynchronously, so the fetching itself is never blocked by them.
+type EventBus struct {
+	lk     sync.RWMutex
+	nextID uint64
+	subs   map[EventType]map[uint64]chan Event
+}
+
+// NewEventBus creates a new EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs: make(map[EventType]map[uint64]chan Event),
+	}
+}
+
+// Subscribe registers a handler for Events of the given type.
+// The returned function unsubscribes the handler.
+func (b *EventBus) Subscribe(tp EventType, handler func(Event)) func() {
+	ch := make(chan Event, eventBufferSize)
+	go func() {
+		for e := range ch {
+			handler(e)
+		}
+	}()
+
+	b.lk.Lock()
+	id := b.nextID
+	b.nextID++
+	if b.subs[tp] == nil {
+		b.subs[tp] = make(map[uint64]chan Event)
+	}
+	b.subs[tp][id] = ch
+	b.lk.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.lk.Lock()
+			delete(b.subs[tp], id)
+			b.lk.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// emit delivers the Event to all the subscribed handlers without blocking.
+func (b *EventBus) emit(e Event) {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	for _, ch := range b.subs[e.Type()] {
+		select {
+		case ch <- e:
+		default:
+			log.Debugw("event bus: dropping event for slow subscriber", "type", e.Type())
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestEventBus_Unsubscribe(t *testing.T) {
+	bus := NewEventBus()
+
+	events := make(chan Event, 2)
+	unsubscribe := bus.Subscribe(BeforeFetchEvent, func(e Event) {
+		events <- e
+	})
+
+	bus.emit(BeforeFetch{Height: 1})
+	// events of other types are not delivered
+	bus.emit(AfterFetch{Height: 1})
+	assert.Equal(t, BeforeFetch{Height: 1}, <-events)
+
+	unsubscribe()
+	unsubscribe() // must be idempotent
+	bus.emit(BeforeFetch{Height: 2})
+
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event after unsubscribe: %v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBlockFetcher_Events(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).DoAndReturn(func(context.Context) (*ctypes.ResultStatus, error) {
+		return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10}}, nil
+	}).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			if *height == 2 {
+				return nil, errFetch
+			}
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	before, after := make(chan Event, 2), make(chan Event, 2)
+	unsubBefore := fetcher.Events().Subscribe(BeforeFetchEvent, func(e Event) {
+		before <- e
+	})
+	t.Cleanup(unsubBefore)
+	unsubAfter := fetcher.Events().Subscribe(AfterFetchEvent, func(e Event) {
+		after <- e
+	})
+	t.Cleanup(unsubAfter)
+
+	height := int64(1)
+	_, err := fetcher.GetBlock(ctx, &height)
+	require.NoError(t, err)
+	assert.Equal(t, BeforeFetch{Height: 1}, <-before)
+	success := (<-after).(AfterFetch)
+	assert.EqualValues(t, 1, success.Height)
+	assert.NoError(t, success.Error)
+
+	height = 2
+	_, err = fetcher.GetBlock(ctx, &height)
+	require.ErrorIs(t, err, errFetch)
+	assert.Equal(t, BeforeFetch{Height: 2}, <-before)
+	failure := (<-after).(AfterFetch)
+	assert.EqualValues(t, 2, failure.Height)
+	assert.ErrorIs(t, failure.Error, errFetch)
+}
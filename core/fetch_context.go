@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// FetchContext describes how a `Block` was fetched by GetBlockWithContext.
+type FetchContext struct {
+	// PeerAddr is the address of the remote Core node which served the block.
+	// It is empty for embedded Core and for blocks served locally.
+	PeerAddr string
+	// Local reports whether the block was served by CachingBlockFetcher or ArchiveBlockFetcher without asking Core.
+	Local bool
+	// Attempts is the amount of requests made to Core, including retries done by RetryMiddleware.
+	Attempts int
+	// BytesReceived is the size of the block received from Core, zero for blocks served locally.
+	BytesReceived int64
+	// Duration is the total time the fetch took.
+	Duration time.Duration
+}
+
+// fetchContextKey keys the FetchContext of the ongoing GetBlockWithContext request in a context.
+type fetchContextKey struct{}
+
+// GetBlockWithContext is GetBlock which also describes how the block was fetched.
+// The FetchContext is returned on failure as well.
+func (f *BlockFetcher) GetBlockWithContext(
+	ctx context.Context,
+	height *int64,
+) (*block.RawBlock, *FetchContext, error) {
+	fctx := &FetchContext{}
+	ctx = context.WithValue(ctx, fetchContextKey{}, fctx)
+
+	start := time.Now()
+	raw, err := f.GetBlock(ctx, height)
+	fctx.Duration = time.Since(start)
+	if fctx.Attempts == 0 {
+		// no RetryMiddleware to count attempts
+		fctx.Attempts = 1
+	}
+	if err != nil {
+		return nil, fctx, err
+	}
+
+	if !fctx.Local {
+		// the endpoint is read once the block is fetched, as a failover Client may switch endpoints meanwhile
+		if f.remote != nil {
+			fctx.PeerAddr = f.remote.Remote()
+		}
+		fctx.BytesReceived = int64(raw.Size())
+	}
+	return raw, fctx, nil
+}
+
+// recordAttempt notes the attempt in the FetchContext of the request, if any.
+func recordAttempt(ctx context.Context, attempt int) {
+	if fctx, ok := ctx.Value(fetchContextKey{}).(*FetchContext); ok {
+		fctx.Attempts = attempt
+	}
+}
+
+// recordLocal notes in the FetchContext of the request, if any, that the block was served without asking Core.
+func recordLocal(ctx context.Context) {
+	if fctx, ok := ctx.Value(fetchContextKey{}).(*FetchContext); ok {
+		fctx.Local = true
+	}
+}
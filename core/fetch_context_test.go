@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockFetcher_GetBlockWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	height := int64(5)
+	raw, fctx, err := newMockBlockFetcher(t, 0).GetBlockWithContext(ctx, &height)
+	require.NoError(t, err)
+	assert.Equal(t, height, raw.Height)
+	assert.Empty(t, fctx.PeerAddr)
+	assert.Equal(t, 1, fctx.Attempts)
+	assert.EqualValues(t, raw.Size(), fctx.BytesReceived)
+	assert.Positive(t, fctx.Duration)
+
+	fetcher := ApplyMiddlewares(newMockBlockFetcher(t, 2), RetryMiddleware{Attempts: 3})
+	_, fctx, err = fetcher.GetBlockWithContext(ctx, &height)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fctx.Attempts)
+
+	fetcher = ApplyMiddlewares(newMockBlockFetcher(t, 2), RetryMiddleware{Attempts: 2})
+	_, fctx, err = fetcher.GetBlockWithContext(ctx, &height)
+	require.Error(t, err)
+	assert.Equal(t, 2, fctx.Attempts)
+	assert.Zero(t, fctx.BytesReceived)
+}
+
+func TestBlockFetcher_GetBlockWithContext_Local(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	fetcher, err := NewCachingBlockFetcher(newMockBlockFetcher(t, 0), DefaultCacheSize)
+	require.NoError(t, err)
+
+	height := int64(5)
+	raw, fctx, err := fetcher.GetBlockWithContext(ctx, &height)
+	require.NoError(t, err)
+	assert.False(t, fctx.Local)
+	assert.EqualValues(t, raw.Size(), fctx.BytesReceived)
+
+	// the cached block is not received from Core again
+	_, fctx, err = fetcher.GetBlockWithContext(ctx, &height)
+	require.NoError(t, err)
+	assert.True(t, fctx.Local)
+	assert.Zero(t, fctx.BytesReceived)
+}
+
+func TestBlockFetcher_GetBlockWithContext_Remote(t *testing.T) {
+	remote, client, err := StartRemoteClient()
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		require.NoError(t, client.Stop())
+		require.NoError(t, remote.Stop())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	fetcher := ApplyMiddlewares(NewBlockFetcher(client), LoggingMiddleware{})
+	require.Eventually(t, func() bool {
+		head, err := fetcher.head(ctx)
+		return err == nil && head > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	// blocks are pruned quickly by the remote node, so the latest one is fetched
+	_, fctx, err := fetcher.GetBlockWithContext(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, remote.Config().RPC.ListenAddress, fctx.PeerAddr)
+	assert.False(t, fctx.Local)
+	assert.Positive(t, fctx.BytesReceived)
+}
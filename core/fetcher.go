@@ -1,9 +1,18 @@
 package core
 
+//go:generate mockgen -destination=mocks/client.go -package=mocks github.com/celestiaorg/celestia-node/core Client
+
 import (
+	"bytes"
 	"context"
-	"fmt"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/celestiaorg/celestia-core/p2p"
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
 	"github.com/celestiaorg/celestia-core/types"
 
 	"github.com/celestiaorg/celestia-node/service/block"
@@ -11,85 +20,586 @@ import (
 
 const newBlockSubscriber = "NewBlock/Events"
 
+// validatorsPerPage is the maximum page size Core allows for validators requests.
+const validatorsPerPage = 100
+
 var newBlockEventQuery = types.QueryForEvent(types.EventNewBlock).String()
 
 type BlockFetcher struct {
 	client Client
+	// remote reports the address of the remote Core node serving requests, nil for embedded Core.
+	remote interface{ Remote() string }
+
+	appVersions *appVersionCache
+	events      *EventBus
+	metrics     *fetcherMetrics
+	health      *health
+	subs        *subscriptions
+	waiters     *heightWaiters
+	*settings
+
+	chainID     *chainIDCache
+	networkInfo *networkInfoCache
+	genesis     *genesisCache
+
+	newBlockCh       chan *block.RawBlock
+	releaseNewBlocks func(context.Context) error
+}
 
-	newBlockCh chan *block.RawBlock
+// settings configure the BlockFetcher, and are shared with the BlockFetchers wrapping it.
+type settings struct {
+	maxBlockInterval  time.Duration
+	slowCallThreshold time.Duration
+	defaultTimeout    time.Duration
+	pingTimeout       time.Duration
+	rangePageSize     int
+
+	blockTimeTolerance time.Duration
+}
+
+// Option configures optional behaviour of the BlockFetcher.
+type Option func(*BlockFetcher)
+
+// WithMaxBlockInterval enables the new block subscription watchdog.
+// If no new block arrives within the interval, the subscription is considered silently dead and is re-established.
+func WithMaxBlockInterval(interval time.Duration) Option {
+	return func(f *BlockFetcher) {
+		f.maxBlockInterval = interval
+	}
+}
+
+// WithSlowCallThreshold enables warnings about calls to Core that take longer than the threshold.
+// The warning is logged as soon as the threshold is exceeded, without waiting for the call to complete.
+func WithSlowCallThreshold(threshold time.Duration) Option {
+	return func(f *BlockFetcher) {
+		f.slowCallThreshold = threshold
+	}
 }
 
 // NewBlockFetcher returns a new `BlockFetcher`.
-func NewBlockFetcher(client Client) *BlockFetcher {
-	return &BlockFetcher{
-		client: client,
+func NewBlockFetcher(client Client, opts ...Option) *BlockFetcher {
+	subs := newSubscriptions(client)
+	f := &BlockFetcher{
+		client:      client,
+		appVersions: newAppVersionCache(),
+		events:      NewEventBus(),
+		metrics:     &fetcherMetrics{},
+		health:      newHealth(),
+		subs:        subs,
+		waiters:     newHeightWaiters(subs),
+		settings:    &settings{},
+		chainID:     &chainIDCache{},
+		networkInfo: &networkInfoCache{},
+		genesis:     &genesisCache{},
+	}
+	if remote, ok := client.(interface{ Remote() string }); ok {
+		f.remote = remote
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// SetDefaultTimeout sets the timeout applied to Get* requests whose context has no deadline.
+// Zero disables the default timeout. It is meant to be set before the BlockFetcher is used.
+func (f *BlockFetcher) SetDefaultTimeout(timeout time.Duration) {
+	f.defaultTimeout = timeout
+}
+
+// Events returns the EventBus notifying about fetches done by the BlockFetcher.
+func (f *BlockFetcher) Events() *EventBus {
+	return f.events
 }
 
 // GetBlock queries Core for a `Block` at the given height.
 func (f *BlockFetcher) GetBlock(ctx context.Context, height *int64) (*block.RawBlock, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	h := heightOf(height)
+	f.events.emit(BeforeFetch{Height: h})
+	start := time.Now()
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		log.Debugw("fetching block", "height", h, "budget_ms", time.Until(deadline).Milliseconds())
+	}
+
+	atomic.AddInt64(&f.metrics.queueDepth, 1)
+	stop := f.warnIfSlow("GetBlock", h, start)
 	raw, err := f.client.Block(ctx, height)
+	stop()
+	atomic.AddInt64(&f.metrics.queueDepth, -1)
+	if err != nil && hasDeadline && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		log.Warnw("fetching block timed out", "height", h,
+			"timeout_ms", deadline.Sub(start).Milliseconds(), "elapsed_ms", time.Since(start).Milliseconds())
+	}
+	if err != nil && height != nil && f.beyondHead(ctx, *height, err) {
+		err = &BlockNotFoundError{Height: *height}
+	}
+	f.events.emit(AfterFetch{Height: h, DurationMs: time.Since(start).Milliseconds(), Error: err})
 	if err != nil {
+		f.metrics.observeFetch(nil, err)
 		return nil, err
 	}
+	f.metrics.observeFetch(raw.Block, nil)
 	return raw.Block, nil
 }
 
+// warnIfSlow logs a warning if the call started at 'start' is still in flight after the slow call threshold.
+// The returned function must be called once the call completes.
+func (f *BlockFetcher) warnIfSlow(method string, height int64, start time.Time) (stop func()) {
+	if f.slowCallThreshold <= 0 {
+		return func() {}
+	}
+	timer := time.AfterFunc(f.slowCallThreshold, func() {
+		log.Warnw("slow Core call", "method", method, "height", height,
+			"elapsed_ms", time.Since(start).Milliseconds())
+	})
+	return func() { timer.Stop() }
+}
+
+// GetBlockWithTimeout is GetBlock limited by the given timeout instead of the default one.
+func (f *BlockFetcher) GetBlockWithTimeout(
+	ctx context.Context,
+	height *int64,
+	timeout time.Duration,
+) (*block.RawBlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return f.GetBlock(ctx, height)
+}
+
+// GetTxProof queries Core for the `Block` at the given height and builds a Merkle inclusion proof for the
+// transaction with the given hash in it. TxNotFoundError is returned if the block has no such transaction.
+func (f *BlockFetcher) GetTxProof(ctx context.Context, txHash []byte, height int64) (*types.TxProof, error) {
+	raw, err := f.GetBlock(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+
+	i := raw.Data.Txs.IndexByHash(txHash)
+	if i == -1 {
+		return nil, &TxNotFoundError{Height: height, Hash: txHash}
+	}
+	proof := raw.Data.Txs.Proof(i)
+	return &proof, nil
+}
+
+// BlockHeightFromHash queries Core for the height of the `Block` with the given hash.
+// Core cannot serve anything smaller than the whole block by hash, so the block is still transferred.
+func (f *BlockFetcher) BlockHeightFromHash(ctx context.Context, hash []byte) (int64, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	res, err := f.client.BlockByHash(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+	if res.Block == nil {
+		return 0, ErrUnknownBlockHash
+	}
+	return res.Block.Height, nil
+}
+
+// GetBlockSize queries Core for the size in bytes of the `Block` at the given height without downloading it.
+// ErrSizeUnavailable is returned if Core does not report the size.
+func (f *BlockFetcher) GetBlockSize(ctx context.Context, height int64) (int64, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	meta, err := f.getBlockMeta(ctx, height)
+	if err != nil {
+		return 0, err
+	}
+	if meta.BlockSize <= 0 {
+		return 0, ErrSizeUnavailable
+	}
+	return int64(meta.BlockSize), nil
+}
+
+// BlockSummary describes a `Block` without its data.
+type BlockSummary struct {
+	Height          int64
+	Hash            []byte
+	Time            time.Time
+	TxCount         int
+	DataSize        int
+	ProposerAddress types.Address
+}
+
+// GetBlockSummary queries Core for the summary of the `Block` at the given height without downloading it.
+func (f *BlockFetcher) GetBlockSummary(ctx context.Context, height int64) (*BlockSummary, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	meta, err := f.getBlockMeta(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockSummary{
+		Height:          meta.Header.Height,
+		Hash:            meta.BlockID.Hash,
+		Time:            meta.Header.Time,
+		TxCount:         meta.NumTxs,
+		DataSize:        meta.BlockSize,
+		ProposerAddress: meta.Header.ProposerAddress,
+	}, nil
+}
+
+// BlockExists checks whether Core has committed a `Block` at the given height without downloading it.
+func (f *BlockFetcher) BlockExists(ctx context.Context, height int64) (bool, error) {
+	header, err := f.getHeader(ctx, &height)
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return header != nil, nil
+}
+
+// GetAppVersion queries Core for the app version of the chain at the given height.
+// Only the header is requested and the result is cached, so a version stable across many heights is fetched
+// only a few times.
+func (f *BlockFetcher) GetAppVersion(ctx context.Context, height int64) (uint64, error) {
+	if version, ok := f.appVersions.get(height); ok {
+		return version, nil
+	}
+
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+	header, err := f.getHeader(ctx, &height)
+	if err != nil {
+		return 0, err
+	}
+
+	f.appVersions.put(header.Height, header.Version.App)
+	return header.Version.App, nil
+}
+
+// GetBlockTime queries Core for the time of the `Block` at the given height.
+// Only the header is requested, so the block itself is not downloaded.
+func (f *BlockFetcher) GetBlockTime(ctx context.Context, height int64) (time.Time, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	header, err := f.getHeader(ctx, &height)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return header.Time, nil
+}
+
+// GetBlockTimeDiff returns the time passed between blocks at heights 'h1' and 'h2'.
+// The difference is negative if 'h2' is older than 'h1'.
+func (f *BlockFetcher) GetBlockTimeDiff(ctx context.Context, h1, h2 int64) (time.Duration, error) {
+	t1, err := f.GetBlockTime(ctx, h1)
+	if err != nil {
+		return 0, err
+	}
+	t2, err := f.GetBlockTime(ctx, h2)
+	if err != nil {
+		return 0, err
+	}
+	return t2.Sub(t1), nil
+}
+
+// GetValidatorPower queries Core for the voting power of the validator with the given address at the given height.
+// ValidatorNotFoundError is returned if there is no such validator in the set.
+func (f *BlockFetcher) GetValidatorPower(ctx context.Context, height int64, address types.Address) (int64, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	perPage := validatorsPerPage
+	for page, seen := 1, 0; ; page++ {
+		vals, err := f.client.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			if f.beyondHead(ctx, height, err) {
+				return 0, &ValidatorSetNotFoundError{Height: height}
+			}
+			return 0, err
+		}
+
+		for _, val := range vals.Validators {
+			if bytes.Equal(val.Address, address) {
+				return val.VotingPower, nil
+			}
+		}
+
+		seen += len(vals.Validators)
+		if len(vals.Validators) == 0 || seen >= vals.Total {
+			return 0, &ValidatorNotFoundError{Height: height, Address: address}
+		}
+	}
+}
+
+// Peers queries Core for the addresses of peers it is connected to, in the 'id@ip' form.
+func (f *BlockFetcher) Peers(ctx context.Context) ([]string, error) {
+	netInfo, err := f.client.NetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]string, 0, len(netInfo.Peers))
+	for _, peer := range netInfo.Peers {
+		peers = append(peers, p2p.IDAddressString(peer.NodeInfo.ID(), peer.RemoteIP))
+	}
+	return peers, nil
+}
+
+// GetGenesisBlock queries Core for the first `Block` of the chain.
+// The genesis block never changes, so it is fetched once and kept for subsequent calls.
+func (f *BlockFetcher) GetGenesisBlock(ctx context.Context) (*block.RawBlock, error) {
+	f.genesis.lk.Lock()
+	defer f.genesis.lk.Unlock()
+	if f.genesis.block != nil {
+		return f.genesis.block, nil
+	}
+
+	height := int64(1)
+	genesis, err := f.GetBlock(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	f.genesis.block = genesis
+	return genesis, nil
+}
+
+// GetGenesisCached returns the genesis `Block` if it was already fetched by GetGenesisBlock, without querying Core.
+func (f *BlockFetcher) GetGenesisCached() (*block.RawBlock, bool) {
+	f.genesis.lk.Lock()
+	defer f.genesis.lk.Unlock()
+	return f.genesis.block, f.genesis.block != nil
+}
+
+// GetUnconfirmedTxs queries Core for up to limit transactions waiting in its mempool.
+// A non-positive limit falls back to the Core's default.
+func (f *BlockFetcher) GetUnconfirmedTxs(ctx context.Context, limit int) ([]types.Tx, error) {
+	var lim *int
+	if limit > 0 {
+		lim = &limit
+	}
+
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+	res, err := f.client.UnconfirmedTxs(ctx, lim)
+	if err != nil {
+		return nil, err
+	}
+	return res.Txs, nil
+}
+
+// GetNumUnconfirmedTxs queries Core for the amount of transactions in its mempool without downloading them.
+func (f *BlockFetcher) GetNumUnconfirmedTxs(ctx context.Context) (int, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	res, err := f.client.NumUnconfirmedTxs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.Total, nil
+}
+
+// GetChainID queries Core for the ID of the chain it runs.
+func (f *BlockFetcher) GetChainID(ctx context.Context) (string, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	status, err := f.client.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	return status.NodeInfo.Network, nil
+}
+
+// CachedGetChainID is GetChainID which queries Core only once, as the chain ID never changes.
+func (f *BlockFetcher) CachedGetChainID(ctx context.Context) (string, error) {
+	f.chainID.lk.Lock()
+	defer f.chainID.lk.Unlock()
+	if f.chainID.id != "" {
+		return f.chainID.id, nil
+	}
+
+	chainID, err := f.GetChainID(ctx)
+	if err != nil {
+		return "", err
+	}
+	f.chainID.id = chainID
+	return chainID, nil
+}
+
+// genesisCache keeps the genesis block once fetched and, like chainIDCache, is shared by reference.
+type genesisCache struct {
+	lk    sync.Mutex
+	block *block.RawBlock
+}
+
+// chainIDCache keeps the chain ID once known. It is shared by reference, so copies of the BlockFetcher made by
+// middlewares use the same cache.
+type chainIDCache struct {
+	lk sync.Mutex
+	id string
+}
+
+// withDefaultTimeout derives a context limited by the default timeout, unless the given one already has a deadline.
+func (f *BlockFetcher) withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || f.defaultTimeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, f.defaultTimeout)
+}
+
+// getHeader queries Core for a `Header` at the given height without downloading the whole block.
+func (f *BlockFetcher) getHeader(ctx context.Context, height *int64) (*types.Header, error) {
+	commit, err := f.client.Commit(ctx, height)
+	if err != nil {
+		if height != nil && f.beyondHead(ctx, *height, err) {
+			return nil, &CommitNotFoundError{Height: *height}
+		}
+		return nil, err
+	}
+	return commit.Header, nil
+}
+
+// getBlockMeta queries Core for the `BlockMeta` at the given height, which summarizes the block without its data.
+func (f *BlockFetcher) getBlockMeta(ctx context.Context, height int64) (*types.BlockMeta, error) {
+	info, err := f.client.BlockchainInfo(ctx, height, height)
+	if err != nil {
+		if f.beyondHead(ctx, height, err) {
+			return nil, &BlockNotFoundError{Height: height}
+		}
+		return nil, err
+	}
+	if len(info.BlockMetas) == 0 {
+		return nil, &BlockNotFoundError{Height: height}
+	}
+	return info.BlockMetas[0], nil
+}
+
+// beyondHead reports whether Core failed with the given error because the given height is not yet produced.
+// Core does not distinguish such heights in errors, so the latest height is checked to find that out. Failures of
+// the context or the connection tell nothing about the height, so the latest height is not checked for them.
+func (f *BlockFetcher) beyondHead(ctx context.Context, height int64, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return false
+	}
+
+	status, err := f.client.Status(ctx)
+	if err != nil {
+		return false
+	}
+	return height > status.SyncInfo.LatestBlockHeight
+}
+
 // SubscribeNewBlockEvent subscribes to new block events from Core, returning
 // a new block event channel on success.
 func (f *BlockFetcher) SubscribeNewBlockEvent(ctx context.Context) (<-chan *block.RawBlock, error) {
 	// start the client if not started yet
 	if !f.client.IsRunning() {
-		return nil, fmt.Errorf("client not running")
+		return nil, ErrClientNotRunning
+	}
+	if f.newBlockCh != nil {
+		return nil, ErrSubscriptionExists
 	}
-	eventChan, err := f.client.Subscribe(ctx, newBlockSubscriber, newBlockEventQuery)
+
+	eventChan, release, err := f.subs.subscribe(ctx, newBlockSubscriber, newBlockEventQuery)
 	if err != nil {
 		return nil, err
 	}
 
 	// create a wrapper channel for translating ResultEvent to "raw" block
-	if f.newBlockCh != nil {
-		return nil, fmt.Errorf("new block event channel exists")
+	newBlockCh := make(chan *block.RawBlock)
+	listenCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	f.newBlockCh = newBlockCh
+	f.releaseNewBlocks = func(ctx context.Context) error {
+		// the listener must be gone before the channel it sends to is closed
+		cancel()
+		<-done
+		return release(ctx)
 	}
 
-	f.newBlockCh = make(chan *block.RawBlock)
-
+	atomic.AddInt64(&f.metrics.activeStreams, 1)
 	go func() {
-		for {
+		defer close(done)
+		f.listenNewBlocks(listenCtx, eventChan, newBlockCh)
+	}()
+	return newBlockCh, nil
+}
+
+// listenNewBlocks translates new block events from Core into "raw" blocks, until the context is canceled.
+func (f *BlockFetcher) listenNewBlocks(
+	ctx context.Context,
+	eventChan <-chan ctypes.ResultEvent,
+	newBlockCh chan<- *block.RawBlock,
+) {
+	defer atomic.AddInt64(&f.metrics.activeStreams, -1)
+
+	watchdog := newWatchdog(f.maxBlockInterval)
+	defer watchdog.stop()
+
+	// the subscription is fresh, so a stale connection noticed before it was made needs no reconnection
+	select {
+	case <-f.health.reconnect:
+	default:
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-watchdog.fired():
+			log.Warnw("no new blocks from Core, resubscribing", "interval", f.maxBlockInterval)
+			f.subs.renew(newBlockEventQuery)
+			watchdog.reset()
+		case <-f.health.reconnect:
+			log.Warn("connection to Core is stale, resubscribing")
+			f.subs.renew(newBlockEventQuery)
+			watchdog.reset()
+		case newEvent, ok := <-eventChan:
+			if !ok {
+				return
+			}
+			watchdog.reset()
+			newBlock, ok := newEvent.Data.(types.EventDataNewBlock)
+			if !ok {
+				log.Warnf("unexpected event: %v", newEvent)
+				continue
+			}
 			select {
+			case newBlockCh <- newBlock.Block:
 			case <-ctx.Done():
 				return
-			case newEvent, ok := <-eventChan:
-				if !ok {
-					return
-				}
-				newBlock, ok := newEvent.Data.(types.EventDataNewBlock)
-				if !ok {
-					log.Warnf("unexpected event: %v", newEvent)
-					continue
-				}
-				select {
-				case f.newBlockCh <- newBlock.Block:
-				case <-ctx.Done():
-					return
-				}
 			}
 		}
-	}()
-
-	return f.newBlockCh, nil
+	}
 }
 
 // UnsubscribeNewBlockEvent stops the subscription to new block events from Core.
 func (f *BlockFetcher) UnsubscribeNewBlockEvent(ctx context.Context) error {
 	// close the new block channel
 	if f.newBlockCh == nil {
-		return fmt.Errorf("no new block event channel found")
+		return ErrNoSubscription
 	}
 	defer func() {
 		close(f.newBlockCh)
 		f.newBlockCh = nil
+		f.releaseNewBlocks = nil
 	}()
 
-	return f.client.Unsubscribe(ctx, newBlockSubscriber, newBlockEventQuery)
+	return f.releaseNewBlocks(ctx)
+}
+
+// heightOf dereferences the optional height, where zero stands for the latest height.
+func heightOf(height *int64) int64 {
+	if height == nil {
+		return 0
+	}
+	return *height
 }
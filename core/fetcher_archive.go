@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+
+	"github.com/celestiaorg/celestia-node/core/blockarchive"
+)
+
+// ArchiveBlockFetcher is a BlockFetcher which serves blocks from a local archive first and falls back to Core
+// for blocks missing there, archiving them once fetched. The archive sits between the BlockFetcher and Core, so
+// every method fetching blocks goes through it, e.g. GetBlockRange or GetTxProof. Requests for the latest
+// block(nil height) always go to Core, as the latest block changes over time.
+type ArchiveBlockFetcher struct {
+	*BlockFetcher
+}
+
+// NewArchiveBlockFetcher wraps the given BlockFetcher with the given archive.
+func NewArchiveBlockFetcher(fetcher *BlockFetcher, store blockarchive.Store) *ArchiveBlockFetcher {
+	return &ArchiveBlockFetcher{
+		BlockFetcher: fetcher.withClient(&archiveClient{Client: fetcher.client, store: store}),
+	}
+}
+
+type archiveClient struct {
+	Client
+
+	store blockarchive.Store
+}
+
+func (c *archiveClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	if height == nil {
+		return c.Client.Block(ctx, height)
+	}
+
+	raw, err := c.store.Get(*height)
+	switch {
+	case err == nil:
+		recordLocal(ctx)
+		// the archive keeps blocks only, so the BlockID of archived ones is unknown
+		return &ctypes.ResultBlock{Block: raw}, nil
+	case !errors.Is(err, blockarchive.ErrNotFound):
+		// the archive is only an optimization, so Core is still asked
+		log.Errorw("reading block from archive", "height", *height, "err", err)
+	}
+
+	res, err := c.Client.Block(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.store.Put(res.Block)
+	if err != nil {
+		log.Errorw("archiving block", "height", *height, "err", err)
+	}
+	return res, nil
+}
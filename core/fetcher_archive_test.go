@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/blockarchive"
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestArchiveBlockFetcher(t *testing.T) {
+	store := blockarchive.NewDatastoreStore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	archived := archivableBlock(1)
+	require.NoError(t, store.Put(archived))
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	// only the block missing in the archive is requested, and only once
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			require.EqualValues(t, 2, *height)
+			return &ctypes.ResultBlock{Block: archivableBlock(*height)}, nil
+		}).Times(1)
+	fetcher := NewArchiveBlockFetcher(NewBlockFetcher(client), store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	for _, height := range []int64{1, 2, 2} {
+		raw, err := fetcher.GetBlock(ctx, &height)
+		require.NoError(t, err)
+		assert.Equal(t, height, raw.Height)
+	}
+
+	raw, err := store.Get(2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, raw.Height)
+
+	// other methods fetching blocks are served from the archive as well
+	blocks, errs := fetcher.BatchGetBlocks(ctx, []int64{1, 2}, 2)
+	require.Equal(t, []error{nil, nil}, errs)
+	assert.EqualValues(t, 1, blocks[0].Height)
+	assert.EqualValues(t, 2, blocks[1].Height)
+	blockCh, errCh := fetcher.GetBlockRange(ctx, 1, 2)
+	for height := int64(1); height <= 2; height++ {
+		raw := <-blockCh
+		require.NotNil(t, raw)
+		assert.Equal(t, height, raw.Height)
+	}
+	require.NoError(t, <-errCh)
+}
+
+// archivableBlock makes a minimal `Block` at the given height which passes encoding round trip.
+func archivableBlock(height int64) *types.Block {
+	b := types.MakeBlock(height, []types.Tx{[]byte("tx")}, nil, nil, types.Messages{}, &types.Commit{})
+	b.ProposerAddress = make([]byte, 20)
+	return b
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/sync/singleflight"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+)
+
+// DefaultCacheSize is the default amount of blocks kept by CachingBlockFetcher.
+const DefaultCacheSize = 128
+
+// errFetchAbandoned signals callers joined to a shared block request that the caller who started it has gone.
+var errFetchAbandoned = errors.New("core: shared block request abandoned")
+
+// CachingBlockFetcher is a BlockFetcher which keeps recently fetched blocks in an LRU cache and deduplicates
+// concurrent requests for the same height, so that subsystems asking for the same block do not load Core twice.
+// The cache sits between the BlockFetcher and Core, so every method fetching blocks goes through it, e.g.
+// GetBlockRange or GetTxProof, and BlockFetcher events and metrics count cached blocks as fetched ones.
+// Requests for the latest block(nil height) are never cached, as the latest block changes over time.
+type CachingBlockFetcher struct {
+	*BlockFetcher
+
+	cache *lru.Cache
+}
+
+// NewCachingBlockFetcher wraps the given BlockFetcher with a cache of the given size.
+func NewCachingBlockFetcher(fetcher *BlockFetcher, size int) (*CachingBlockFetcher, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CachingBlockFetcher{
+		BlockFetcher: fetcher.withClient(&cachingClient{Client: fetcher.client, cache: cache}),
+		cache:        cache,
+	}, nil
+}
+
+// Evict removes cached blocks below the given height, e.g. once they are not needed by any subsystem anymore.
+func (f *CachingBlockFetcher) Evict(below int64) {
+	for _, key := range f.cache.Keys() {
+		if key.(int64) < below {
+			f.cache.Remove(key)
+		}
+	}
+}
+
+type cachingClient struct {
+	Client
+
+	cache    *lru.Cache
+	inflight singleflight.Group
+}
+
+func (c *cachingClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	if height == nil {
+		return c.Client.Block(ctx, height)
+	}
+
+	for {
+		if res, ok := c.cache.Get(*height); ok {
+			recordLocal(ctx)
+			return res.(*ctypes.ResultBlock), nil
+		}
+
+		resCh := c.inflight.DoChan(strconv.FormatInt(*height, 10), func() (interface{}, error) {
+			// the block may have been cached by a request completed after the cache check above
+			if res, ok := c.cache.Get(*height); ok {
+				return res, nil
+			}
+
+			res, err := c.Client.Block(ctx, height)
+			if err != nil {
+				if ctx.Err() != nil {
+					// the error is caused by the caller who started the request and not by Core
+					return nil, errFetchAbandoned
+				}
+				return nil, err
+			}
+
+			c.cache.Add(*height, res)
+			return res, nil
+		})
+
+		select {
+		case res := <-resCh:
+			switch {
+			case res.Err == nil:
+				return res.Val.(*ctypes.ResultBlock), nil
+			case !errors.Is(res.Err, errFetchAbandoned):
+				return nil, res.Err
+			case ctx.Err() != nil:
+				return nil, ctx.Err()
+			}
+			// the caller who started the request has gone, so it is requested again for this one
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
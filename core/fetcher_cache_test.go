@@ -0,0 +1,138 @@
+package core
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestCachingBlockFetcher_GetBlock(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			atomic.AddInt32(&calls, 1)
+			if height != nil {
+				<-release
+				return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+			}
+			return &ctypes.ResultBlock{Block: &types.Block{}}, nil
+		}).AnyTimes()
+	fetcher, err := NewCachingBlockFetcher(NewBlockFetcher(client), DefaultCacheSize)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	height := int64(5)
+	// concurrent requests for the same height are deduplicated, either by joining the request in flight or by
+	// finding the block it cached, so Core is asked once however the requests interleave
+	var wg sync.WaitGroup
+	blocks := make([]*types.Block, 2)
+	for i := range blocks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b, err := fetcher.GetBlock(ctx, &height)
+			assert.NoError(t, err)
+			blocks[i] = b
+		}(i)
+	}
+	// wait until a request reaches Core before unblocking it
+	for atomic.LoadInt32(&calls) == 0 {
+		runtime.Gosched()
+	}
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	assert.Same(t, blocks[0], blocks[1])
+
+	// cached heights do not hit Core
+	b, err := fetcher.GetBlock(ctx, &height)
+	require.NoError(t, err)
+	assert.Same(t, blocks[0], b)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	// so do other methods fetching blocks
+	b, err = fetcher.GetBlockWithTimeout(ctx, &height, time.Second)
+	require.NoError(t, err)
+	assert.Same(t, blocks[0], b)
+	blocks, errs := fetcher.BatchGetBlocks(ctx, []int64{height, height}, 2)
+	require.Equal(t, []error{nil, nil}, errs)
+	assert.Same(t, b, blocks[0])
+	assert.Same(t, b, blocks[1])
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	// the latest block is never cached
+	_, err = fetcher.GetBlock(ctx, nil)
+	require.NoError(t, err)
+	_, err = fetcher.GetBlock(ctx, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+
+	// evicted heights are fetched again
+	fetcher.Evict(height + 1)
+	_, err = fetcher.GetBlock(ctx, &height)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, atomic.LoadInt32(&calls))
+}
+
+func TestCachingBlockFetcher_GetBlock_Abandoned(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			atomic.AddInt32(&calls, 1)
+			select {
+			case <-release:
+				return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}).AnyTimes()
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10}}, nil).AnyTimes()
+	fetcher, err := NewCachingBlockFetcher(NewBlockFetcher(client), DefaultCacheSize)
+	require.NoError(t, err)
+
+	height := int64(5)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	abandoned := make(chan error, 1)
+	go func() {
+		_, err := fetcher.GetBlock(ctx, &height)
+		abandoned <- err
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		runtime.Gosched()
+	}
+
+	fetched := make(chan *types.Block, 1)
+	go func() {
+		b, err := fetcher.GetBlock(context.Background(), &height)
+		assert.NoError(t, err)
+		fetched <- b
+	}()
+
+	// the caller who started the request goes away, which must not fail the other one
+	cancel()
+	assert.ErrorIs(t, <-abandoned, context.Canceled)
+	close(release)
+	b := <-fetched
+	require.NotNil(t, b)
+	assert.Equal(t, height, b.Height)
+}
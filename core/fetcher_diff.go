@@ -0,0 +1,82 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// BlockDiff describes how namespaces with messages differ between two blocks.
+// All the namespaces are sorted.
+type BlockDiff struct {
+	// AddedNamespaces have messages only in the second block.
+	AddedNamespaces []namespace.ID
+	// RemovedNamespaces have messages only in the first block.
+	RemovedNamespaces []namespace.ID
+	// ChangedNamespaces have messages in both blocks, but of a different total size.
+	ChangedNamespaces []NamespaceDelta
+}
+
+// NamespaceDelta is the difference in message bytes of a namespace between two blocks.
+type NamespaceDelta struct {
+	ID    namespace.ID
+	Bytes int
+}
+
+// GetBlockDiff queries Core for blocks at heights 'h1' and 'h2' and compares the namespaces of their messages.
+func (f *BlockFetcher) GetBlockDiff(ctx context.Context, h1, h2 int64) (*BlockDiff, error) {
+	b1, err := f.GetBlock(ctx, &h1)
+	if err != nil {
+		return nil, err
+	}
+	b2, err := f.GetBlock(ctx, &h2)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes1, sizes2 := namespaceSizes(b1), namespaceSizes(b2)
+	diff := &BlockDiff{}
+	for nID, size1 := range sizes1 {
+		size2, ok := sizes2[nID]
+		switch {
+		case !ok:
+			diff.RemovedNamespaces = append(diff.RemovedNamespaces, namespace.ID(nID))
+		case size1 != size2:
+			diff.ChangedNamespaces = append(diff.ChangedNamespaces, NamespaceDelta{
+				ID:    namespace.ID(nID),
+				Bytes: size2 - size1,
+			})
+		}
+	}
+	for nID := range sizes2 {
+		if _, ok := sizes1[nID]; !ok {
+			diff.AddedNamespaces = append(diff.AddedNamespaces, namespace.ID(nID))
+		}
+	}
+
+	sortNamespaces(diff.AddedNamespaces)
+	sortNamespaces(diff.RemovedNamespaces)
+	sort.Slice(diff.ChangedNamespaces, func(i, j int) bool {
+		return bytes.Compare(diff.ChangedNamespaces[i].ID, diff.ChangedNamespaces[j].ID) < 0
+	})
+	return diff, nil
+}
+
+// namespaceSizes sums the sizes of message data in the block per namespace.
+func namespaceSizes(raw *block.RawBlock) map[string]int {
+	sizes := make(map[string]int)
+	for _, msg := range raw.Data.Messages.MessagesList {
+		sizes[string(msg.NamespaceID)] += len(msg.Data)
+	}
+	return sizes
+}
+
+func sortNamespaces(nIDs []namespace.ID) {
+	sort.Slice(nIDs, func(i, j int) bool {
+		return bytes.Compare(nIDs[i], nIDs[j]) < 0
+	})
+}
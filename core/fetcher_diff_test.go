@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_GetBlockDiff(t *testing.T) {
+	var (
+		kept    = namespace.ID{1, 1, 1, 1, 1, 1, 1, 1}
+		grown   = namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+		shrunk  = namespace.ID{3, 3, 3, 3, 3, 3, 3, 3}
+		removed = namespace.ID{4, 4, 4, 4, 4, 4, 4, 4}
+		added   = namespace.ID{5, 5, 5, 5, 5, 5, 5, 5}
+	)
+	message := func(nID namespace.ID, size int) types.Message {
+		return types.Message{NamespaceID: nID, Data: make([]byte, size)}
+	}
+	blocks := map[int64]*types.Block{
+		1: {Header: types.Header{Height: 1}, Data: types.Data{Messages: types.Messages{MessagesList: []types.Message{
+			message(kept, 10), message(grown, 10), message(shrunk, 10), message(shrunk, 10), message(removed, 10),
+		}}}},
+		2: {Header: types.Header{Height: 2}, Data: types.Data{Messages: types.Messages{MessagesList: []types.Message{
+			message(added, 5), message(grown, 25), message(shrunk, 5), message(kept, 10),
+		}}}},
+	}
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			return &ctypes.ResultBlock{Block: blocks[*height]}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	diff, err := fetcher.GetBlockDiff(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []namespace.ID{added}, diff.AddedNamespaces)
+	assert.Equal(t, []namespace.ID{removed}, diff.RemovedNamespaces)
+	assert.Equal(t, []NamespaceDelta{{ID: grown, Bytes: 15}, {ID: shrunk, Bytes: -15}}, diff.ChangedNamespaces)
+
+	// swapping the heights reverses the diff
+	diff, err = fetcher.GetBlockDiff(context.Background(), 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []namespace.ID{removed}, diff.AddedNamespaces)
+	assert.Equal(t, []namespace.ID{added}, diff.RemovedNamespaces)
+	assert.Equal(t, []NamespaceDelta{{ID: grown, Bytes: -15}, {ID: shrunk, Bytes: 15}}, diff.ChangedNamespaces)
+
+	// identical blocks have no diff
+	diff, err = fetcher.GetBlockDiff(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &BlockDiff{}, diff)
+}
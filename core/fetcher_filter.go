@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// BlockEventFilter decides which new blocks are delivered by SubscribeNewBlockEventWithFilter.
+type BlockEventFilter interface {
+	Accept(*block.RawBlock) bool
+}
+
+// NamespaceFilter accepts blocks containing messages of the namespace.
+type NamespaceFilter struct {
+	ID namespace.ID
+}
+
+func (f NamespaceFilter) Accept(raw *block.RawBlock) bool {
+	for _, msg := range raw.Data.Messages.MessagesList {
+		if msg.NamespaceID.Equal(f.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// MinSizeFilter accepts blocks of at least Bytes in size.
+type MinSizeFilter struct {
+	Bytes int
+}
+
+func (f MinSizeFilter) Accept(raw *block.RawBlock) bool {
+	return raw.Size() >= f.Bytes
+}
+
+// SubscribeNewBlockEventWithFilter subscribes to new blocks from Core accepted by the filter.
+// The returned channel is closed and the subscription is released once the context is canceled.
+// Unlike SubscribeNewBlockEvent, any amount of filtered subscriptions can be made at once, as they share a single
+// subscription to Core with SubscribeNewBlockEvent.
+func (f *BlockFetcher) SubscribeNewBlockEventWithFilter(
+	ctx context.Context,
+	filter BlockEventFilter,
+) (<-chan *block.RawBlock, error) {
+	eventChan, release, err := f.subscribeNewBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCh := make(chan *block.RawBlock)
+	go func() {
+		defer close(blockCh)
+		defer release()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case newEvent, ok := <-eventChan:
+				if !ok {
+					return
+				}
+				newBlock, ok := newEvent.Data.(types.EventDataNewBlock)
+				if !ok {
+					log.Warnf("unexpected event: %v", newEvent)
+					continue
+				}
+				if !filter.Accept(newBlock.Block) {
+					continue
+				}
+				select {
+				case blockCh <- newBlock.Block:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return blockCh, nil
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+func TestBlockFetcher_SubscribeNewBlockEventWithFilter(t *testing.T) {
+	nID := namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}
+	blockWith := func(height int64, nIDs ...namespace.ID) *types.Block {
+		msgs := types.Messages{}
+		for _, nID := range nIDs {
+			msgs.MessagesList = append(msgs.MessagesList, types.Message{NamespaceID: nID, Data: []byte("data")})
+		}
+		return types.MakeBlock(height, nil, nil, nil, msgs, &types.Commit{})
+	}
+	other := namespace.ID{8, 7, 6, 5, 4, 3, 2, 1}
+	blocks := []*types.Block{
+		blockWith(1, other),
+		blockWith(2, nID),
+		blockWith(3),
+		blockWith(4, other, nID),
+	}
+
+	var filtered []int64
+	for _, tt := range []struct {
+		name     string
+		filter   BlockEventFilter
+		expected []int64
+	}{
+		{name: "namespace", filter: NamespaceFilter{ID: nID}, expected: []int64{2, 4}},
+		{name: "min size", filter: MinSizeFilter{Bytes: blocks[3].Size()}, expected: []int64{4}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			eventChan := make(chan ctypes.ResultEvent, len(blocks))
+			for _, b := range blocks {
+				eventChan <- ctypes.ResultEvent{Data: types.EventDataNewBlock{Block: b}}
+			}
+
+			client := mocks.NewMockClient(gomock.NewController(t))
+			client.EXPECT().IsRunning().Return(true)
+			client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(eventChan, nil)
+			client.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			fetcher := NewBlockFetcher(client)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			blockCh, err := fetcher.SubscribeNewBlockEventWithFilter(ctx, tt.filter)
+			require.NoError(t, err)
+
+			filtered = filtered[:0]
+			for range tt.expected {
+				filtered = append(filtered, (<-blockCh).Height)
+			}
+			assert.Equal(t, tt.expected, filtered)
+
+			cancel()
+			for open := true; open; {
+				_, open = <-blockCh
+			}
+		})
+	}
+}
+
+func TestBlockFetcher_SubscribeNewBlockEventWithFilter_Remote(t *testing.T) {
+	fetcher := startRemoteFetcher(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// remote Core keys subscriptions by query, so filtered subscriptions must not take over each other's events
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+	filterCtx, cancelFilter := context.WithCancel(ctx)
+	filtered, err := fetcher.SubscribeNewBlockEventWithFilter(filterCtx, MinSizeFilter{})
+	require.NoError(t, err)
+
+	receive := func(blockCh <-chan *block.RawBlock) *block.RawBlock {
+		select {
+		case raw, ok := <-blockCh:
+			require.True(t, ok)
+			return raw
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription starved")
+			return nil
+		}
+	}
+	assert.NotZero(t, receive(filtered).Height)
+	assert.NotZero(t, receive(newBlockChan).Height)
+
+	// releasing the filtered subscription must not end the other
+	cancelFilter()
+	for open := true; open; {
+		_, open = <-filtered
+	}
+	// the remote node produces blocks faster than they are read, so some of them may be dropped
+	prev := receive(newBlockChan)
+	for i := 0; i < 3; i++ {
+		raw := receive(newBlockChan)
+		assert.Greater(t, raw.Height, prev.Height)
+		prev = raw
+	}
+
+	cancel()
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(context.Background()))
+}
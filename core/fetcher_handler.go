@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// OnNewBlock calls the handler for every new block produced by Core, blocking until the context is canceled or the
+// handler fails, in which case the handler's error is returned.
+// Unlike SubscribeNewBlockEvent, any amount of handlers can be run at once, as they share a single subscription to
+// Core with SubscribeNewBlockEvent.
+func (f *BlockFetcher) OnNewBlock(ctx context.Context, handler func(*block.RawBlock) error) error {
+	eventChan, release, err := f.subscribeNewBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case newEvent, ok := <-eventChan:
+			if !ok {
+				return nil
+			}
+			newBlock, ok := newEvent.Data.(types.EventDataNewBlock)
+			if !ok {
+				log.Warnf("unexpected event: %v", newEvent)
+				continue
+			}
+			err = handler(newBlock.Block)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribeNewBlocks consumes new block events from the subscription shared with SubscribeNewBlockEvent.
+// The returned function releases the events.
+func (f *BlockFetcher) subscribeNewBlocks(ctx context.Context) (<-chan ctypes.ResultEvent, func(), error) {
+	if !f.client.IsRunning() {
+		return nil, nil, ErrClientNotRunning
+	}
+
+	eventChan, release, err := f.subs.subscribe(ctx, newBlockSubscriber, newBlockEventQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	atomic.AddInt64(&f.metrics.activeStreams, 1)
+	return eventChan, func() {
+		atomic.AddInt64(&f.metrics.activeStreams, -1)
+		// the subscription context may be canceled already
+		err := release(context.Background())
+		if err != nil {
+			log.Errorw("unsubscribing from new blocks", "err", err)
+		}
+	}, nil
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+func TestBlockFetcher_OnNewBlock(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// handler errors stop delivery
+	errStop := errors.New("stop")
+	var handled int
+	err := fetcher.OnNewBlock(ctx, func(raw *block.RawBlock) error {
+		require.NotNil(t, raw)
+		handled++
+		if handled == 3 {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 3, handled)
+
+	// context cancellation stops delivery cleanly
+	cancelCtx, cancelHandler := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- fetcher.OnNewBlock(cancelCtx, func(*block.RawBlock) error {
+			cancelHandler()
+			return nil
+		})
+	}()
+	select {
+	case err = <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler was not stopped")
+	}
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_OnNewBlock_Remote(t *testing.T) {
+	fetcher := startRemoteFetcher(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// remote Core keys subscriptions by query, so handlers must not take over SubscribeNewBlockEvent's events
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	errStop := errors.New("stop")
+	for i := 0; i < 2; i++ {
+		err = fetcher.OnNewBlock(ctx, func(raw *block.RawBlock) error {
+			require.NotNil(t, raw)
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+	}
+
+	// returning handlers must not end the subscription
+	// the remote node produces blocks faster than they are read, so some of them may be dropped
+	receive := func() *block.RawBlock {
+		select {
+		case raw, ok := <-newBlockChan:
+			require.True(t, ok)
+			return raw
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription starved")
+			return nil
+		}
+	}
+	prev := receive()
+	for i := 0; i < 3; i++ {
+		raw := receive()
+		assert.Greater(t, raw.Height, prev.Height)
+		prev = raw
+	}
+
+	cancel()
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(context.Background()))
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/celestiaorg/celestia-core/types"
+)
+
+const newBlockHeaderSubscriber = "NewBlockHeader/Events"
+
+// blockMetasPerRequest is the maximum amount of block metas Core returns for a BlockchainInfo request.
+const blockMetasPerRequest = 20
+
+var newBlockHeaderEventQuery = types.QueryForEvent(types.EventNewBlockHeader).String()
+
+// SubscribeBlockHeaders subscribes to headers of new blocks from Core, so that block data is never transferred.
+// The returned channel is closed and the subscription is released once the context is canceled.
+// Any amount of header subscriptions can be made at once, as they share a single subscription to Core.
+func (f *BlockFetcher) SubscribeBlockHeaders(ctx context.Context) (<-chan types.Header, error) {
+	if !f.client.IsRunning() {
+		return nil, ErrClientNotRunning
+	}
+
+	eventChan, release, err := f.subs.subscribe(ctx, newBlockHeaderSubscriber, newBlockHeaderEventQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	headerCh := make(chan types.Header)
+	atomic.AddInt64(&f.metrics.activeStreams, 1)
+	go func() {
+		defer atomic.AddInt64(&f.metrics.activeStreams, -1)
+		defer close(headerCh)
+		defer func() {
+			// the subscription context is already canceled here
+			err := release(context.Background())
+			if err != nil {
+				log.Errorw("unsubscribing from new block headers", "err", err)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case newEvent, ok := <-eventChan:
+				if !ok {
+					return
+				}
+				newHeader, ok := newEvent.Data.(types.EventDataNewBlockHeader)
+				if !ok {
+					log.Warnf("unexpected event: %v", newEvent)
+					continue
+				}
+				select {
+				case headerCh <- newHeader.Header:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return headerCh, nil
+}
+
+// GetBlockHeaders queries Core for headers of blocks from height 'from' to 'to' inclusive, in height order,
+// without downloading the blocks.
+func (f *BlockFetcher) GetBlockHeaders(ctx context.Context, from, to int64) ([]*types.Header, error) {
+	if from < 1 || from > to {
+		return nil, fmt.Errorf("core: invalid header range [%d:%d]", from, to)
+	}
+
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	headers := make([]*types.Header, 0, to-from+1)
+	for lo := from; lo <= to; lo += blockMetasPerRequest {
+		hi := lo + blockMetasPerRequest - 1
+		if hi > to {
+			hi = to
+		}
+
+		info, err := f.client.BlockchainInfo(ctx, lo, hi)
+		if err != nil {
+			if f.beyondHead(ctx, lo, err) {
+				return nil, &BlockNotFoundError{Height: lo}
+			}
+			return nil, err
+		}
+
+		// Core returns metas from the highest one and silently skips heights it does not have
+		for height, i := lo, len(info.BlockMetas)-1; height <= hi; height, i = height+1, i-1 {
+			if i < 0 || info.BlockMetas[i].Header.Height != height {
+				return nil, &BlockNotFoundError{Height: height}
+			}
+			headers = append(headers, &info.BlockMetas[i].Header)
+		}
+	}
+	return headers, nil
+}
+
+// StreamBlockHeaders continuously delivers headers in height order starting from the given height, like
+// GetBlockStream does for blocks. The returned channel is closed once the context is canceled or an error happens.
+func (f *BlockFetcher) StreamBlockHeaders(ctx context.Context, from int64) (<-chan *types.Header, error) {
+	// subscribe before catching up, so that no heights are missed in between
+	newHeaderCh, err := f.SubscribeBlockHeaders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	headerCh := make(chan *types.Header, blockMetasPerRequest)
+	go func() {
+		defer close(headerCh)
+
+		next := from
+		sendUpTo := func(height int64) error {
+			for next <= height {
+				to := next + blockMetasPerRequest - 1
+				if to > height {
+					to = height
+				}
+
+				headers, err := f.GetBlockHeaders(ctx, next, to)
+				if err != nil {
+					return err
+				}
+				for _, header := range headers {
+					select {
+					case headerCh <- header:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				next = to + 1
+			}
+			return nil
+		}
+
+		status, err := f.client.Status(ctx)
+		if err == nil {
+			err = sendUpTo(status.SyncInfo.LatestBlockHeight)
+		}
+		for err == nil {
+			header, ok := <-newHeaderCh
+			if !ok {
+				return
+			}
+			err = sendUpTo(header.Height)
+		}
+		if ctx.Err() == nil {
+			log.Errorw("streaming block headers", "height", next, "err", err)
+		}
+	}()
+
+	return headerCh, nil
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_SubscribeBlockHeaders(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	headerCh, err := fetcher.SubscribeBlockHeaders(ctx)
+	require.NoError(t, err)
+
+	prev := <-headerCh
+	for i := 0; i < 3; i++ {
+		header := <-headerCh
+		assert.Equal(t, prev.Height+1, header.Height)
+
+		raw, err := fetcher.GetBlock(ctx, &header.Height)
+		require.NoError(t, err)
+		assert.Equal(t, raw.Header.Hash(), header.Hash())
+		prev = header
+	}
+
+	cancel()
+	// the channel is closed once the context is canceled
+	for open := true; open; {
+		_, open = <-headerCh
+	}
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_GetBlockHeaders(t *testing.T) {
+	const head = 45
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: head}}, nil).AnyTimes()
+	// mimics Core, which clamps the range to its head and returns metas from the highest one
+	client.EXPECT().BlockchainInfo(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, min, max int64) (*ctypes.ResultBlockchainInfo, error) {
+			require.LessOrEqual(t, max-min+1, int64(blockMetasPerRequest))
+			if max > head {
+				max = head
+			}
+			if min > max {
+				return nil, assert.AnError
+			}
+
+			info := &ctypes.ResultBlockchainInfo{LastHeight: head}
+			for height := max; height >= min; height-- {
+				info.BlockMetas = append(info.BlockMetas, &types.BlockMeta{Header: types.Header{Height: height}})
+			}
+			return info, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var tests = []struct {
+		name     string
+		from, to int64
+		notFound bool
+		invalid  bool
+	}{
+		{name: "single", from: 7, to: 7},
+		{name: "one page", from: 1, to: blockMetasPerRequest},
+		{name: "page and one", from: 1, to: blockMetasPerRequest + 1},
+		{name: "up to head", from: 3, to: head},
+		{name: "beyond head", from: 40, to: head + 1, notFound: true},
+		{name: "from beyond head", from: head + 1, to: head + 5, notFound: true},
+		{name: "reversed", from: 5, to: 4, invalid: true},
+		{name: "zero", from: 0, to: 4, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, err := fetcher.GetBlockHeaders(ctx, tt.from, tt.to)
+			switch {
+			case tt.notFound:
+				assert.True(t, IsNotFound(err))
+			case tt.invalid:
+				assert.Error(t, err)
+				assert.False(t, IsNotFound(err))
+			default:
+				require.NoError(t, err)
+				require.Len(t, headers, int(tt.to-tt.from+1))
+				for i, header := range headers {
+					assert.Equal(t, tt.from+int64(i), header.Height)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockFetcher_StreamBlockHeaders(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	// let Core produce some history first
+	head := generateBlocks(t, fetcher, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	headerCh, err := fetcher.StreamBlockHeaders(ctx, 1)
+	require.NoError(t, err)
+	// read past the head to ensure the stream switches to new headers
+	for height := int64(1); height <= head.Height+3; height++ {
+		header, ok := <-headerCh
+		require.True(t, ok)
+		assert.Equal(t, height, header.Height)
+	}
+
+	cancel()
+	for open := true; open; {
+		_, open = <-headerCh
+	}
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_SubscribeBlockHeaders_Remote(t *testing.T) {
+	fetcher := startRemoteFetcher(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// remote Core keys subscriptions by query, so concurrent ones must not take over each other
+	first, err := fetcher.SubscribeBlockHeaders(ctx)
+	require.NoError(t, err)
+	secondCtx, cancelSecond := context.WithCancel(ctx)
+	second, err := fetcher.SubscribeBlockHeaders(secondCtx)
+	require.NoError(t, err)
+
+	receive := func(headerCh <-chan types.Header) types.Header {
+		select {
+		case header, ok := <-headerCh:
+			require.True(t, ok)
+			return header
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription starved")
+			return types.Header{}
+		}
+	}
+	assert.NotZero(t, receive(first).Height)
+	assert.NotZero(t, receive(second).Height)
+
+	// releasing one of them must not end the other
+	cancelSecond()
+	for open := true; open; {
+		_, open = <-second
+	}
+	// the remote node produces blocks faster than they are read, so some of them may be dropped
+	prev := receive(first)
+	for i := 0; i < 3; i++ {
+		header := receive(first)
+		assert.Greater(t, header.Height, prev.Height)
+		prev = header
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// BlockFetcherMetrics is a snapshot of the BlockFetcher metrics, meant for custom exporters.
+type BlockFetcherMetrics struct {
+	// BlocksFetched counts blocks successfully fetched with GetBlock.
+	BlocksFetched uint64
+	// BlocksFetchErrors counts failed GetBlock requests.
+	BlocksFetchErrors uint64
+	// BytesReceived sums the sizes of fetched blocks.
+	BytesReceived uint64
+	// ActiveStreams is the amount of currently open subscriptions to Core events.
+	ActiveStreams int64
+	// QueueDepth is the amount of GetBlock requests currently waiting for Core.
+	QueueDepth int64
+}
+
+// fetcherMetrics maintains metrics of the BlockFetcher. It is shared by reference, so copies of the BlockFetcher
+// made by middlewares report into the same metrics.
+type fetcherMetrics struct {
+	blocksFetched     uint64
+	blocksFetchErrors uint64
+	bytesReceived     uint64
+	activeStreams     int64
+	queueDepth        int64
+}
+
+// Metrics returns a snapshot of the BlockFetcher metrics.
+func (f *BlockFetcher) Metrics() BlockFetcherMetrics {
+	return BlockFetcherMetrics{
+		BlocksFetched:     atomic.LoadUint64(&f.metrics.blocksFetched),
+		BlocksFetchErrors: atomic.LoadUint64(&f.metrics.blocksFetchErrors),
+		BytesReceived:     atomic.LoadUint64(&f.metrics.bytesReceived),
+		ActiveStreams:     atomic.LoadInt64(&f.metrics.activeStreams),
+		QueueDepth:        atomic.LoadInt64(&f.metrics.queueDepth),
+	}
+}
+
+func (m *fetcherMetrics) observeFetch(raw *block.RawBlock, err error) {
+	if err != nil {
+		atomic.AddUint64(&m.blocksFetchErrors, 1)
+		return
+	}
+	atomic.AddUint64(&m.blocksFetched, 1)
+	atomic.AddUint64(&m.bytesReceived, uint64(raw.Size()))
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_Metrics(t *testing.T) {
+	errFetch := errors.New("unavailable")
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10}}, nil).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			if *height > 5 {
+				return nil, errFetch
+			}
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).Times(7)
+	client.EXPECT().IsRunning().Return(true).AnyTimes()
+	client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(make(chan ctypes.ResultEvent), nil)
+	client.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var size uint64
+	for height := int64(1); height <= 7; height++ {
+		raw, err := fetcher.GetBlock(ctx, &height)
+		if height > 5 {
+			require.ErrorIs(t, err, errFetch)
+			continue
+		}
+		require.NoError(t, err)
+		size += uint64(raw.Size())
+	}
+
+	metrics := fetcher.Metrics()
+	assert.EqualValues(t, 5, metrics.BlocksFetched)
+	assert.EqualValues(t, 2, metrics.BlocksFetchErrors)
+	assert.Equal(t, size, metrics.BytesReceived)
+	assert.Zero(t, metrics.QueueDepth)
+	assert.Zero(t, metrics.ActiveStreams)
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	headerCh, err := fetcher.SubscribeBlockHeaders(subCtx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fetcher.Metrics().ActiveStreams)
+
+	subCancel()
+	for open := true; open; {
+		_, open = <-headerCh
+	}
+	assert.Eventually(t, func() bool {
+		return fetcher.Metrics().ActiveStreams == 0
+	}, time.Second, 10*time.Millisecond)
+}
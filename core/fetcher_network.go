@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// NetworkInfo describes the network the Core node runs in and the node itself.
+type NetworkInfo struct {
+	ChainID        string
+	NetworkVersion string
+	NodeID         string
+	ListenAddr     string
+}
+
+// networkInfoCache keeps the NetworkInfo once known and, like chainIDCache, is shared by reference.
+type networkInfoCache struct {
+	lk   sync.Mutex
+	info *NetworkInfo
+}
+
+// GetNetworkInfo queries Core for information about its network and itself.
+// The information is static, so it is queried only once and kept for subsequent calls.
+func (f *BlockFetcher) GetNetworkInfo(ctx context.Context) (*NetworkInfo, error) {
+	f.networkInfo.lk.Lock()
+	defer f.networkInfo.lk.Unlock()
+	if f.networkInfo.info != nil {
+		return f.networkInfo.info, nil
+	}
+
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	status, err := f.client.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f.networkInfo.info = &NetworkInfo{
+		ChainID:        status.NodeInfo.Network,
+		NetworkVersion: status.NodeInfo.Version,
+		NodeID:         string(status.NodeInfo.DefaultNodeID),
+		ListenAddr:     status.NodeInfo.ListenAddr,
+	}
+	return f.networkInfo.info, nil
+}
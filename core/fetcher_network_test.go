@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-core/p2p"
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_GetNetworkInfo(t *testing.T) {
+	nodeInfo := p2p.DefaultNodeInfo{
+		DefaultNodeID: "3a5e1d7a2c5b2e8f1b8f1c1c9d1e1f1a2b3c4d5e",
+		ListenAddr:    "tcp://0.0.0.0:26656",
+		Network:       "celestia-test",
+		Version:       "0.34.11",
+	}
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	// the info is static, so it is requested once
+	client.EXPECT().Status(gomock.Any()).Return(&ctypes.ResultStatus{NodeInfo: nodeInfo}, nil).Times(1)
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	for i := 0; i < 2; i++ {
+		info, err := fetcher.GetNetworkInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "celestia-test", info.ChainID)
+		assert.Equal(t, "0.34.11", info.NetworkVersion)
+		assert.Equal(t, "3a5e1d7a2c5b2e8f1b8f1c1c9d1e1f1a2b3c4d5e", info.NodeID)
+		assert.Equal(t, "tcp://0.0.0.0:26656", info.ListenAddr)
+	}
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// DefaultRangePageSize is the default amount of blocks GetBlockRange requests from Core at once.
+const DefaultRangePageSize = 16
+
+// WithRangePageSize sets the amount of blocks GetBlockRange requests from Core at once.
+// It also bounds the amount of blocks buffered for a slow reader.
+func WithRangePageSize(size int) Option {
+	return func(f *BlockFetcher) {
+		f.rangePageSize = size
+	}
+}
+
+// GetBlockRange delivers blocks from height 'from' to 'to' inclusive in height order.
+// Core has no RPC to stream a range of blocks, so blocks of each page are requested concurrently instead.
+// The range ends once all the blocks are delivered, the context is canceled or an error happens, in which case the
+// error is delivered on the error channel. Both channels are closed when the range ends.
+func (f *BlockFetcher) GetBlockRange(ctx context.Context, from, to int64) (<-chan *block.RawBlock, <-chan error) {
+	pageSize := f.rangePageSize
+	if pageSize <= 0 {
+		pageSize = DefaultRangePageSize
+	}
+
+	blockCh, errCh := make(chan *block.RawBlock, pageSize), make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		defer close(blockCh)
+
+		if from > to {
+			errCh <- fmt.Errorf("core: invalid block range [%d:%d]", from, to)
+			return
+		}
+
+		for page := from; page <= to; page += int64(pageSize) {
+			last := page + int64(pageSize) - 1
+			if last > to {
+				last = to
+			}
+
+			blocks, err := f.getPage(ctx, page, last)
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			for _, raw := range blocks {
+				select {
+				case blockCh <- raw:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+
+	return blockCh, errCh
+}
+
+// getPage concurrently fetches blocks from height 'from' to 'to' inclusive.
+func (f *BlockFetcher) getPage(ctx context.Context, from, to int64) ([]*block.RawBlock, error) {
+	blocks := make([]*block.RawBlock, to-from+1)
+	errg, ctx := errgroup.WithContext(ctx)
+	for i := range blocks {
+		i, height := i, from+int64(i)
+		errg.Go(func() (err error) {
+			blocks[i], err = f.GetBlock(ctx, &height)
+			return err
+		})
+	}
+	return blocks, errg.Wait()
+}
+
+// BatchGetBlocks fetches blocks at the given heights with at most 'concurrency' requests to Core in flight.
+// Blocks and errors are indexed as the heights, so a failure to fetch one height does not affect the others.
+// A non-positive concurrency falls back to the range page size.
+func (f *BlockFetcher) BatchGetBlocks(
+	ctx context.Context,
+	heights []int64,
+	concurrency int,
+) ([]*block.RawBlock, []error) {
+	if concurrency <= 0 {
+		concurrency = f.rangePageSize
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultRangePageSize
+	}
+
+	blocks, errs := make([]*block.RawBlock, len(heights)), make([]error, len(heights))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range heights {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			blocks[i], errs[i] = f.GetBlock(ctx, &heights[i])
+		}(i)
+	}
+	wg.Wait()
+	return blocks, errs
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_GetBlockRange(t *testing.T) {
+	const from, to = 3, 42
+
+	var requests int32
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			atomic.AddInt32(&requests, 1)
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client, WithRangePageSize(7))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	blockCh, errCh := fetcher.GetBlockRange(ctx, from, to)
+	height := int64(from)
+	for raw := range blockCh {
+		assert.Equal(t, height, raw.Height)
+		height++
+	}
+	require.NoError(t, <-errCh)
+	assert.EqualValues(t, to+1, height)
+	assert.EqualValues(t, to-from+1, atomic.LoadInt32(&requests))
+}
+
+func TestBlockFetcher_GetBlockRange_Error(t *testing.T) {
+	errFetch := errors.New("unavailable")
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 100}}, nil).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			if *height == 10 {
+				return nil, errFetch
+			}
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client, WithRangePageSize(4))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	blockCh, errCh := fetcher.GetBlockRange(ctx, 1, 20)
+	var last int64
+	for raw := range blockCh {
+		last = raw.Height
+	}
+	require.ErrorIs(t, <-errCh, errFetch)
+	// only full pages before the failed one are delivered
+	assert.EqualValues(t, 8, last)
+
+	_, errCh = fetcher.GetBlockRange(ctx, 5, 1)
+	assert.Error(t, <-errCh)
+}
+
+func TestBlockFetcher_BatchGetBlocks(t *testing.T) {
+	const concurrency = 3
+	errFailed := errors.New("failed")
+
+	var inFlight, maxInFlight int32
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 100}}, nil).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			if *height%3 == 0 {
+				return nil, errFailed
+			}
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	heights := []int64{10, 3, 7, 1, 9, 20, 15, 4, 2, 30}
+	blocks, errs := fetcher.BatchGetBlocks(context.Background(), heights, concurrency)
+	require.Len(t, blocks, len(heights))
+	require.Len(t, errs, len(heights))
+	for i, height := range heights {
+		if height%3 == 0 {
+			assert.ErrorIs(t, errs[i], errFailed)
+			assert.Nil(t, blocks[i])
+			continue
+		}
+		require.NoError(t, errs[i])
+		assert.Equal(t, height, blocks[i].Height)
+	}
+	assert.LessOrEqual(t, maxInFlight, int32(concurrency))
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// blockStreamBuffer is the amount of blocks buffered by GetBlockStream to absorb bursts of new blocks.
+const blockStreamBuffer = 16
+
+// GetBlockStream continuously delivers blocks in height order starting from the given height.
+// Historical blocks are fetched first, and once the stream catches up with the head it switches to new blocks as
+// they are produced by Core. The stream ends once the context is canceled or an error happens, in which case the
+// error is delivered on the error channel. Both channels are closed when the stream ends.
+func (f *BlockFetcher) GetBlockStream(ctx context.Context, from int64) (<-chan *block.RawBlock, <-chan error) {
+	blockCh, errCh := make(chan *block.RawBlock, blockStreamBuffer), make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		defer close(blockCh)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		err := f.streamBlocks(ctx, from, blockCh)
+		if err != nil && ctx.Err() == nil {
+			errCh <- err
+		}
+	}()
+
+	return blockCh, errCh
+}
+
+func (f *BlockFetcher) streamBlocks(ctx context.Context, next int64, blockCh chan<- *block.RawBlock) error {
+	// subscribe before catching up, so that no heights are missed in between
+	headerCh, err := f.SubscribeBlockHeaders(ctx)
+	if err != nil {
+		return err
+	}
+
+	sendUpTo := func(height int64) error {
+		for ; next <= height; next++ {
+			raw, err := f.GetBlock(ctx, &next)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case blockCh <- raw:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+
+	status, err := f.client.Status(ctx)
+	if err != nil {
+		return err
+	}
+	err = sendUpTo(status.SyncInfo.LatestBlockHeight)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case header, ok := <-headerCh:
+			if !ok {
+				return ctx.Err()
+			}
+			err = sendUpTo(header.Height)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockFetcher_GetBlockStream(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	// let Core produce some history first
+	head := generateBlocks(t, fetcher, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	blockCh, errCh := fetcher.GetBlockStream(ctx, 1)
+	// read past the head to ensure the stream switches to new blocks
+	for height := int64(1); height <= head.Height+3; height++ {
+		select {
+		case raw := <-blockCh:
+			require.NotNil(t, raw)
+			assert.Equal(t, height, raw.Height)
+		case err := <-errCh:
+			t.Fatal(err)
+		}
+	}
+
+	cancel()
+	for open := true; open; {
+		_, open = <-blockCh
+	}
+	assert.NoError(t, <-errCh)
+
+	require.NoError(t, client.Stop())
+}
@@ -2,10 +2,25 @@ package core
 
 import (
 	"context"
+	"errors"
+	"net"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/celestiaorg/celestia-core/crypto/ed25519"
+	tmjson "github.com/celestiaorg/celestia-core/libs/json"
+	"github.com/celestiaorg/celestia-core/p2p"
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+	"github.com/celestiaorg/celestia-node/service/block"
 )
 
 func TestBlockFetcher_GetBlock_and_SubscribeNewBlockEvent(t *testing.T) {
@@ -31,3 +46,547 @@ func TestBlockFetcher_GetBlock_and_SubscribeNewBlockEvent(t *testing.T) {
 	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
 	require.NoError(t, client.Stop())
 }
+
+func TestBlockFetcher_GetAppVersion(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	raw := generateBlocks(t, fetcher, 1)
+
+	version, err := fetcher.GetAppVersion(ctx, raw.Height)
+	require.NoError(t, err)
+	assert.Equal(t, raw.Header.Version.App, version)
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_GetValidatorPower(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	raw := generateBlocks(t, fetcher, 1)
+	status, err := client.Status(ctx)
+	require.NoError(t, err)
+
+	power, err := fetcher.GetValidatorPower(ctx, raw.Height, status.ValidatorInfo.Address)
+	require.NoError(t, err)
+	assert.Equal(t, status.ValidatorInfo.VotingPower, power)
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_GetValidatorPower_Paging(t *testing.T) {
+	const pageSize = 3
+	set := make([]*types.Validator, 10)
+	for i := range set {
+		set[i] = types.NewValidator(ed25519.GenPrivKey().PubKey(), int64(i+1))
+	}
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Validators(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64, page, _ *int) (*ctypes.ResultValidators, error) {
+			from := (*page - 1) * pageSize
+			to := from + pageSize
+			if to > len(set) {
+				to = len(set)
+			}
+			return &ctypes.ResultValidators{
+				BlockHeight: *height,
+				Validators:  set[from:to],
+				Count:       to - from,
+				Total:       len(set),
+			}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	for _, val := range set {
+		power, err := fetcher.GetValidatorPower(ctx, 1, val.Address)
+		require.NoError(t, err)
+		assert.Equal(t, val.VotingPower, power)
+	}
+
+	_, err := fetcher.GetValidatorPower(ctx, 1, ed25519.GenPrivKey().PubKey().Address())
+	var notFound *ValidatorNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.EqualValues(t, 1, notFound.Height)
+}
+
+func TestBlockFetcher_NotFound(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	future := generateBlocks(t, fetcher, 1).Height + 1000
+
+	_, err := fetcher.GetBlock(ctx, &future)
+	var blockErr *BlockNotFoundError
+	require.ErrorAs(t, err, &blockErr)
+	assert.Equal(t, future, blockErr.Height)
+
+	_, err = fetcher.GetAppVersion(ctx, future)
+	var commitErr *CommitNotFoundError
+	require.ErrorAs(t, err, &commitErr)
+	assert.Equal(t, future, commitErr.Height)
+
+	_, err = fetcher.GetValidatorPower(ctx, future, types.Address{})
+	var valSetErr *ValidatorSetNotFoundError
+	require.ErrorAs(t, err, &valSetErr)
+	assert.Equal(t, future, valSetErr.Height)
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_NotFound_NotChecked(t *testing.T) {
+	var tests = []struct {
+		name string
+		err  error
+	}{
+		{name: "canceled", err: context.Canceled},
+		{name: "deadline", err: context.DeadlineExceeded},
+		{name: "connection", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mocks.NewMockClient(gomock.NewController(t))
+			// such failures tell nothing about the height, so the head is never asked for
+			client.EXPECT().Status(gomock.Any()).Times(0)
+			client.EXPECT().Block(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+			fetcher := NewBlockFetcher(client)
+
+			height := int64(5)
+			_, err := fetcher.GetBlock(context.Background(), &height)
+			assert.ErrorIs(t, err, tt.err)
+			assert.False(t, IsNotFound(err))
+		})
+	}
+}
+
+func TestBlockFetcher_Peers(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// the mock Core node runs alone
+	peers, err := fetcher.Peers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	require.NoError(t, client.Stop())
+
+	mockClient := mocks.NewMockClient(gomock.NewController(t))
+	mockClient.EXPECT().NetInfo(gomock.Any()).Return(&ctypes.ResultNetInfo{
+		NPeers: 2,
+		Peers: []ctypes.Peer{
+			{NodeInfo: p2p.DefaultNodeInfo{DefaultNodeID: "a"}, RemoteIP: "10.0.0.1"},
+			{NodeInfo: p2p.DefaultNodeInfo{DefaultNodeID: "b"}, RemoteIP: "10.0.0.2"},
+		},
+	}, nil)
+	fetcher = NewBlockFetcher(mockClient)
+	peers, err = fetcher.Peers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@10.0.0.1", "b@10.0.0.2"}, peers)
+}
+
+func TestBlockFetcher_GetChainID(t *testing.T) {
+	const chainID = "celestia-test"
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	// once by GetChainID and once by the first CachedGetChainID
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{NodeInfo: p2p.DefaultNodeInfo{Network: chainID}}, nil).
+		Times(2)
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	id, err := fetcher.GetChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, chainID, id)
+
+	for i := 0; i < 3; i++ {
+		id, err = fetcher.CachedGetChainID(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, chainID, id)
+	}
+}
+
+func TestBlockFetcher_GetGenesisBlock(t *testing.T) {
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		Return(&ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: 1}}}, nil).
+		Times(1)
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	_, ok := fetcher.GetGenesisCached()
+	assert.False(t, ok)
+
+	for i := 0; i < 3; i++ {
+		genesis, err := fetcher.GetGenesisBlock(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, genesis.Height)
+	}
+
+	genesis, ok := fetcher.GetGenesisCached()
+	require.True(t, ok)
+	assert.EqualValues(t, 1, genesis.Height)
+}
+
+func TestBlockFetcher_DefaultTimeout(t *testing.T) {
+	const defaultTimeout = time.Minute
+
+	deadlines := make(chan time.Time, 1)
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			deadlines <- deadline
+			return &ctypes.ResultBlock{Block: &types.Block{}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+	fetcher.SetDefaultTimeout(defaultTimeout)
+
+	// the default is applied to a context without a deadline
+	start := time.Now()
+	_, err := fetcher.GetBlock(context.Background(), nil)
+	require.NoError(t, err)
+	assert.WithinDuration(t, start.Add(defaultTimeout), <-deadlines, time.Second)
+
+	// the deadline of the parent context is honoured over the default
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	t.Cleanup(cancel)
+	parent, _ := ctx.Deadline()
+	_, err = fetcher.GetBlock(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parent, <-deadlines)
+
+	// the explicit timeout overrides the default
+	start = time.Now()
+	_, err = fetcher.GetBlockWithTimeout(context.Background(), nil, time.Second)
+	require.NoError(t, err)
+	assert.WithinDuration(t, start.Add(time.Second), <-deadlines, time.Second/2)
+}
+
+func TestBlockFetcher_GetBlockTime(t *testing.T) {
+	genesis := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	blockTime := func(height int64) time.Time {
+		return genesis.Add(time.Duration(height) * 15 * time.Second)
+	}
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Commit(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+			header := &types.Header{Height: *height, Time: blockTime(*height)}
+			return &ctypes.ResultCommit{SignedHeader: types.SignedHeader{Header: header}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	for _, height := range []int64{1, 2, 10} {
+		tm, err := fetcher.GetBlockTime(ctx, height)
+		require.NoError(t, err)
+		assert.True(t, blockTime(height).Equal(tm))
+	}
+
+	diff, err := fetcher.GetBlockTimeDiff(ctx, 2, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, diff)
+
+	diff, err = fetcher.GetBlockTimeDiff(ctx, 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, -2*time.Minute, diff)
+}
+
+func TestBlockFetcher_GetTxProof(t *testing.T) {
+	txs := types.Txs{[]byte("tx1"), []byte("tx2"), []byte("tx3"), []byte("tx4"), []byte("tx5")}
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			return &ctypes.ResultBlock{Block: &types.Block{
+				Header: types.Header{Height: *height},
+				Data:   types.Data{Txs: txs},
+			}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	for _, tx := range txs {
+		proof, err := fetcher.GetTxProof(ctx, tx.Hash(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, tx, proof.Data)
+		assert.NoError(t, proof.Validate(txs.Hash()))
+	}
+
+	_, err := fetcher.GetTxProof(ctx, types.Tx("unknown").Hash(), 1)
+	var txErr *TxNotFoundError
+	require.ErrorAs(t, err, &txErr)
+	assert.EqualValues(t, 1, txErr.Height)
+}
+
+func TestBlockFetcher_BlockHeightFromHash(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	current := generateBlocks(t, fetcher, 2)
+	height, err := fetcher.BlockHeightFromHash(ctx, current.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, current.Height, height)
+
+	_, err = fetcher.BlockHeightFromHash(ctx, make([]byte, 32))
+	assert.ErrorIs(t, err, ErrUnknownBlockHash)
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_GetBlockSize(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	current := generateBlocks(t, fetcher, 2)
+	size, err := fetcher.GetBlockSize(ctx, current.Height)
+	require.NoError(t, err)
+	assert.InEpsilon(t, current.Size(), size, 0.05)
+
+	_, err = fetcher.GetBlockSize(ctx, current.Height+1000)
+	assert.True(t, IsNotFound(err))
+
+	require.NoError(t, client.Stop())
+
+	mockClient := mocks.NewMockClient(gomock.NewController(t))
+	mockClient.EXPECT().BlockchainInfo(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&ctypes.ResultBlockchainInfo{BlockMetas: []*types.BlockMeta{{}}}, nil)
+	_, err = NewBlockFetcher(mockClient).GetBlockSize(ctx, 1)
+	assert.ErrorIs(t, err, ErrSizeUnavailable)
+}
+
+func TestBlockFetcher_GetBlockSummary(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	current := generateBlocks(t, fetcher, 2)
+	summary, err := fetcher.GetBlockSummary(ctx, current.Height)
+	require.NoError(t, err)
+	assert.Equal(t, current.Height, summary.Height)
+	assert.EqualValues(t, current.Hash(), summary.Hash)
+	assert.True(t, current.Time.Equal(summary.Time))
+	assert.Equal(t, len(current.Data.Txs), summary.TxCount)
+	assert.Equal(t, current.Size(), summary.DataSize)
+	assert.Equal(t, current.ProposerAddress, summary.ProposerAddress)
+
+	_, err = fetcher.GetBlockSummary(ctx, current.Height+1000)
+	assert.True(t, IsNotFound(err))
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_GetUnconfirmedTxs(t *testing.T) {
+	txs := []types.Tx{[]byte("tx1"), []byte("tx2"), []byte("tx3")}
+
+	var gotLimit *int
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().UnconfirmedTxs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
+			gotLimit = limit
+			// pass through JSON to ensure transaction bytes survive the RPC encoding
+			raw, err := tmjson.Marshal(&ctypes.ResultUnconfirmedTxs{Count: len(txs), Total: len(txs), Txs: txs})
+			if err != nil {
+				return nil, err
+			}
+			res := new(ctypes.ResultUnconfirmedTxs)
+			return res, tmjson.Unmarshal(raw, res)
+		}).AnyTimes()
+	client.EXPECT().NumUnconfirmedTxs(gomock.Any()).
+		DoAndReturn(func(context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+			return &ctypes.ResultUnconfirmedTxs{Count: len(txs), Total: len(txs)}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	got, err := fetcher.GetUnconfirmedTxs(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, txs, got)
+	require.NotNil(t, gotLimit)
+	assert.Equal(t, 2, *gotLimit)
+
+	_, err = fetcher.GetUnconfirmedTxs(ctx, 0)
+	require.NoError(t, err)
+	assert.Nil(t, gotLimit)
+
+	num, err := fetcher.GetNumUnconfirmedTxs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(txs), num)
+}
+
+// generateBlocks waits for Core to produce 'n' new blocks and returns the last one.
+func generateBlocks(t *testing.T, fetcher *BlockFetcher, n int) (last *block.RawBlock) {
+	ctx, cancel := context.WithCancel(context.Background())
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	for i := 0; i < n; i++ {
+		last = <-newBlockChan
+	}
+
+	cancel()
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(context.Background()))
+	return last
+}
+
+// startRemoteFetcher returns a BlockFetcher talking to a started remote Core node, which are both stopped once the
+// test ends.
+func startRemoteFetcher(t *testing.T) *BlockFetcher {
+	remote, client, err := StartRemoteClient()
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		require.NoError(t, client.Stop())
+		require.NoError(t, remote.Stop())
+	})
+	return NewBlockFetcher(client)
+}
+
+func TestBlockFetcher_BlockExists(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	current := generateBlocks(t, fetcher, 2)
+
+	var tests = []struct {
+		name   string
+		height int64
+		exists bool
+	}{
+		{name: "past", height: current.Height - 1, exists: true},
+		{name: "current", height: current.Height, exists: true},
+		{name: "future", height: current.Height + 1000, exists: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := fetcher.BlockExists(ctx, tt.height)
+			require.NoError(t, err)
+			assert.Equal(t, tt.exists, exists)
+		})
+	}
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_GetBlock_DeadlineLogging(t *testing.T) {
+	logs := observeLogs(t)
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).DoAndReturn(func(context.Context) (*ctypes.ResultStatus, error) {
+		return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10}}, nil
+	}).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *int64) (*ctypes.ResultBlock, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	t.Cleanup(cancel)
+
+	height := int64(5)
+	_, err := fetcher.GetBlock(ctx, &height)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	budget := logs.FilterMessage("fetching block").All()
+	require.Len(t, budget, 1)
+	assert.Contains(t, budget[0].ContextMap(), "budget_ms")
+
+	timeout := logs.FilterMessage("fetching block timed out").All()
+	require.Len(t, timeout, 1)
+	assert.Equal(t, zap.WarnLevel, timeout[0].Level)
+	fields := timeout[0].ContextMap()
+	assert.EqualValues(t, height, fields["height"])
+	assert.Contains(t, fields, "timeout_ms")
+	assert.Contains(t, fields, "elapsed_ms")
+}
+
+func TestBlockFetcher_SlowCallThreshold(t *testing.T) {
+	var tests = []struct {
+		name      string
+		threshold time.Duration
+		warned    bool
+	}{
+		{name: "exceeded", threshold: 100 * time.Millisecond, warned: true},
+		{name: "not exceeded", threshold: 300 * time.Millisecond, warned: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := observeLogs(t)
+			client := mocks.NewMockClient(gomock.NewController(t))
+			client.EXPECT().Block(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+					time.Sleep(200 * time.Millisecond)
+					return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+				}).Times(1)
+			fetcher := NewBlockFetcher(client, WithSlowCallThreshold(tt.threshold))
+
+			height := int64(5)
+			_, err := fetcher.GetBlock(context.Background(), &height)
+			require.NoError(t, err)
+
+			slow := logs.FilterMessage("slow Core call").All()
+			if !tt.warned {
+				assert.Empty(t, slow)
+				return
+			}
+			require.Len(t, slow, 1)
+			assert.Equal(t, zap.WarnLevel, slow[0].Level)
+			fields := slow[0].ContextMap()
+			assert.Equal(t, "GetBlock", fields["method"])
+			assert.EqualValues(t, height, fields["height"])
+			assert.GreaterOrEqual(t, fields["elapsed_ms"], int64(100))
+		})
+	}
+}
+
+// observeLogs redirects logs of the package into the returned observer for the duration of the test.
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zap.DebugLevel)
+	orig := log.SugaredLogger
+	log.SugaredLogger = *zap.New(core).Sugar()
+	t.Cleanup(func() {
+		log.SugaredLogger = orig
+	})
+	return logs
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// WithBlockTimeTolerance sets how far from the requested time the block found by GetBlockAtTime may be.
+// The search stops at the first block within the tolerance, so a bigger one makes the search cheaper.
+func WithBlockTimeTolerance(tolerance time.Duration) Option {
+	return func(f *BlockFetcher) {
+		f.blockTimeTolerance = tolerance
+	}
+}
+
+// GetBlockAtTime queries Core for the `Block` produced the closest to the given time.
+// Block times are searched through headers only, so only the found block is downloaded.
+// Only blocks still stored by Core are searched, so pruned blocks are never requested.
+func (f *BlockFetcher) GetBlockAtTime(ctx context.Context, t time.Time) (*block.RawBlock, error) {
+	// every Core call of the search has its own timeout
+	statusCtx, cancel := f.withDefaultTimeout(ctx)
+	status, err := f.client.Status(statusCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	// block times are cached, as the same heights are compared more than once
+	times := make(map[int64]time.Time)
+	timeAt := func(height int64) (time.Time, error) {
+		if tm, ok := times[height]; ok {
+			return tm, nil
+		}
+		tm, err := f.GetBlockTime(ctx, height)
+		if err != nil {
+			return time.Time{}, err
+		}
+		times[height] = tm
+		return tm, nil
+	}
+
+	// find the first block produced not before the given time
+	earliest := status.SyncInfo.EarliestBlockHeight
+	if earliest < 1 {
+		earliest = 1
+	}
+	lo, hi := earliest, status.SyncInfo.LatestBlockHeight
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		tm, err := timeAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if distance(tm, t) <= f.blockTimeTolerance {
+			return f.GetBlock(ctx, &mid)
+		}
+
+		if tm.Before(t) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	closest := lo
+	if lo > earliest {
+		// the previous block may be closer
+		after, err := timeAt(lo)
+		if err != nil {
+			return nil, err
+		}
+		before, err := timeAt(lo - 1)
+		if err != nil {
+			return nil, err
+		}
+		if distance(before, t) < distance(after, t) {
+			closest = lo - 1
+		}
+	}
+	return f.GetBlock(ctx, &closest)
+}
+
+// distance returns the absolute time between 'a' and 'b'.
+func distance(a, b time.Time) time.Duration {
+	if d := a.Sub(b); d >= 0 {
+		return d
+	}
+	return b.Sub(a)
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_GetBlockAtTime(t *testing.T) {
+	const latest = 100
+	genesis := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	blockTime := func(height int64) time.Time {
+		return genesis.Add(time.Duration(height) * 15 * time.Second)
+	}
+
+	lookups := 0
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: latest}}, nil).AnyTimes()
+	client.EXPECT().Commit(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+			lookups++
+			header := &types.Header{Height: *height, Time: blockTime(*height)}
+			return &ctypes.ResultCommit{SignedHeader: types.SignedHeader{Header: header}}, nil
+		}).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var tests = []struct {
+		name     string
+		time     time.Time
+		expected int64
+	}{
+		{name: "exact", time: blockTime(42), expected: 42},
+		{name: "closer to previous", time: blockTime(42).Add(5 * time.Second), expected: 42},
+		{name: "closer to next", time: blockTime(42).Add(10 * time.Second), expected: 43},
+		{name: "before genesis", time: genesis.Add(-time.Hour), expected: 1},
+		{name: "after head", time: blockTime(latest).Add(time.Hour), expected: latest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups = 0
+			b, err := fetcher.GetBlockAtTime(ctx, tt.time)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, b.Height)
+			// the search is logarithmic, with each height looked up once
+			assert.LessOrEqual(t, lookups, 8)
+		})
+	}
+}
+
+func TestBlockFetcher_GetBlockAtTime_Tolerance(t *testing.T) {
+	genesis := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 100}}, nil).AnyTimes()
+	client.EXPECT().Commit(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+			header := &types.Header{Height: *height, Time: genesis.Add(time.Duration(*height) * time.Minute)}
+			return &ctypes.ResultCommit{SignedHeader: types.SignedHeader{Header: header}}, nil
+		}).Times(1)
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).Times(1)
+	fetcher := NewBlockFetcher(client, WithBlockTimeTolerance(time.Hour))
+
+	// the first probed block is within the tolerance, so the search stops there
+	b, err := fetcher.GetBlockAtTime(context.Background(), genesis.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), b.Height)
+}
+
+func TestBlockFetcher_GetBlockAtTime_Pruned(t *testing.T) {
+	const earliest, latest = 50, 100
+	genesis := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{
+			EarliestBlockHeight: earliest,
+			LatestBlockHeight:   latest,
+		}}, nil).AnyTimes()
+	client.EXPECT().Commit(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+			if *height < earliest {
+				return nil, fmt.Errorf("height %d is not available, lowest height is %d", *height, earliest)
+			}
+			header := &types.Header{Height: *height, Time: genesis.Add(time.Duration(*height) * time.Minute)}
+			return &ctypes.ResultCommit{SignedHeader: types.SignedHeader{Header: header}}, nil
+		}).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: *height}}}, nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	// pruned blocks are never searched, so the earliest stored block is the closest
+	b, err := fetcher.GetBlockAtTime(context.Background(), genesis)
+	require.NoError(t, err)
+	assert.Equal(t, int64(earliest), b.Height)
+}
@@ -0,0 +1,346 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+// WatchHeight returns a channel closed once Core has committed the block at the given height.
+// The channel is closed right away if the height is already available, and is never closed if the context is
+// canceled first. All watched heights are signaled from a single subscription to new blocks, shared with
+// SubscribeNewBlockEvent, which is only held while heights are watched.
+func (f *BlockFetcher) WatchHeight(ctx context.Context, height int64) (<-chan struct{}, error) {
+	head, err := f.head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return f.watchHeight(ctx, height, head)
+}
+
+// NotifyOnHeight delivers the block at the given height once Core has committed it.
+// A block that is already committed is fetched right away. The channel is closed after the block is delivered, or
+// without delivering it if the context is canceled or fetching fails. Like WatchHeight, it never makes a
+// subscription of its own, so it does not interfere with SubscribeNewBlockEvent.
+func (f *BlockFetcher) NotifyOnHeight(ctx context.Context, height int64) (<-chan *block.RawBlock, error) {
+	availableCh, err := f.WatchHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCh := make(chan *block.RawBlock, 1)
+	go func() {
+		defer close(blockCh)
+		select {
+		case <-availableCh:
+		case <-ctx.Done():
+			return
+		}
+
+		raw, err := f.GetBlock(ctx, &height)
+		if err != nil {
+			log.Errorw("fetching watched block", "height", height, "err", err)
+			return
+		}
+		blockCh <- raw
+	}()
+	return blockCh, nil
+}
+
+// WatchHeights delivers each of the given heights once Core has committed the block at it.
+// Heights that are already available are delivered right away in ascending order, the rest as they are committed.
+// The channel is closed once all the heights are delivered, the context is canceled or watching fails.
+func (f *BlockFetcher) WatchHeights(ctx context.Context, heights []int64) <-chan int64 {
+	availableCh := make(chan int64, len(heights))
+	go func() {
+		defer close(availableCh)
+		// releases the heights still watched on return
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		head, err := f.head(ctx)
+		if err != nil {
+			log.Errorw("watching heights", "err", err)
+			return
+		}
+
+		unique := make(map[int64]struct{}, len(heights))
+		for _, height := range heights {
+			unique[height] = struct{}{}
+		}
+		sorted := make([]int64, 0, len(unique))
+		for height := range unique {
+			sorted = append(sorted, height)
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		// all the heights are watched at once, so that a single subscription serves them
+		waitChs := make([]<-chan struct{}, len(sorted))
+		for i, height := range sorted {
+			waitChs[i], err = f.watchHeight(ctx, height, head)
+			if err != nil {
+				log.Errorw("watching heights", "err", err)
+				return
+			}
+		}
+		// blocks are committed in ascending order, so waiting in that order delivers heights as they are committed
+		for i, waitCh := range waitChs {
+			select {
+			case <-waitCh:
+				// the channel fits all the heights, so notifying never blocks
+				availableCh <- sorted[i]
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return availableCh
+}
+
+// head queries Core for the latest committed height.
+func (f *BlockFetcher) head(ctx context.Context) (int64, error) {
+	ctx, cancel := f.withDefaultTimeout(ctx)
+	defer cancel()
+
+	status, err := f.client.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// watchHeight returns a channel closed once the given height is committed, knowing the given head is.
+// The height stops being watched once the context is canceled.
+func (f *BlockFetcher) watchHeight(ctx context.Context, height, head int64) (<-chan struct{}, error) {
+	availableCh := make(chan struct{})
+	if height <= head {
+		close(availableCh)
+		return availableCh, nil
+	}
+	if !f.client.IsRunning() {
+		return nil, ErrClientNotRunning
+	}
+
+	err := f.waiters.wait(ctx, height, availableCh)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-availableCh:
+		case <-ctx.Done():
+			f.waiters.cancel(height, availableCh)
+		}
+	}()
+	return availableCh, nil
+}
+
+// heightWaiters signals the channels waiting for heights as new blocks are committed.
+// It subscribes to new blocks once the first height is waited for, and releases the subscription once none is.
+// A block committed before the subscription is made is caught up with the next one.
+type heightWaiters struct {
+	subs *subscriptions
+
+	lk       sync.Mutex
+	byHeight map[int64][]chan struct{}
+	// stop stops listening to new blocks, nil if not listening
+	stop func()
+	// release releases the subscription listened to
+	release func(context.Context) error
+	// subscribing is closed once the ongoing subscription to new blocks is made or fails, nil if none is ongoing
+	subscribing chan struct{}
+}
+
+func newHeightWaiters(subs *subscriptions) *heightWaiters {
+	return &heightWaiters{
+		subs:     subs,
+		byHeight: make(map[int64][]chan struct{}),
+	}
+}
+
+// wait registers the channel to be closed once the given height is committed, subscribing to new blocks first if
+// not listening to them yet.
+func (w *heightWaiters) wait(ctx context.Context, height int64, ch chan struct{}) error {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	for w.stop == nil {
+		// Core is called outside of the lock, so that waiters are signaled and canceled meanwhile
+		if w.subscribing != nil {
+			subscribing := w.subscribing
+			w.lk.Unlock()
+			select {
+			case <-subscribing:
+			case <-ctx.Done():
+				w.lk.Lock()
+				return ctx.Err()
+			}
+			w.lk.Lock()
+			continue
+		}
+
+		subscribing := make(chan struct{})
+		w.subscribing = subscribing
+		w.lk.Unlock()
+		eventChan, release, err := w.subscribe(ctx)
+		w.lk.Lock()
+		w.subscribing = nil
+		close(subscribing)
+		if err != nil {
+			return err
+		}
+		w.listen(eventChan, release)
+	}
+	w.byHeight[height] = append(w.byHeight[height], ch)
+	return nil
+}
+
+// cancel forgets the channel waiting for the given height, if it is not signaled yet.
+func (w *heightWaiters) cancel(height int64, ch chan struct{}) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	chs := w.byHeight[height]
+	for i := range chs {
+		if chs[i] == ch {
+			chs = append(chs[:i], chs[i+1:]...)
+			break
+		}
+	}
+	if len(chs) > 0 {
+		w.byHeight[height] = chs
+	} else {
+		delete(w.byHeight, height)
+	}
+	w.stopIfIdle()
+}
+
+// subscribe subscribes to new blocks, giving up after the subscription timeout.
+func (w *heightWaiters) subscribe(ctx context.Context) (<-chan ctypes.ResultEvent, func(context.Context) error, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.subs.timeout)
+	defer cancel()
+	return w.subs.subscribe(ctx, newBlockSubscriber, newBlockEventQuery)
+}
+
+// listen signals the waiters as new blocks of the subscription arrive. It must be called under the lock.
+func (w *heightWaiters) listen(eventChan <-chan ctypes.ResultEvent, release func(context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.release = release
+	w.stop = func() {
+		cancel()
+		err := w.release(context.Background())
+		if err != nil {
+			log.Errorw("unsubscribing from new blocks", "err", err)
+		}
+	}
+	go w.signal(ctx, eventChan)
+}
+
+func (w *heightWaiters) signal(ctx context.Context, eventChan <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case newEvent, ok := <-eventChan:
+			if !ok {
+				eventChan = w.relisten(ctx)
+				if eventChan == nil {
+					return
+				}
+				continue
+			}
+			newBlock, ok := newEvent.Data.(types.EventDataNewBlock)
+			if !ok {
+				log.Warnf("unexpected event: %v", newEvent)
+				continue
+			}
+			w.signalUpTo(newBlock.Block.Height)
+		}
+	}
+}
+
+// signalUpTo closes the channels waiting for the given head or any height below it.
+func (w *heightWaiters) signalUpTo(head int64) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	for height, chs := range w.byHeight {
+		if height > head {
+			continue
+		}
+		for _, ch := range chs {
+			close(ch)
+		}
+		delete(w.byHeight, height)
+	}
+	w.stopIfIdle()
+}
+
+// relisten subscribes to new blocks again after Core ended the subscription, backing off exponentially on failures,
+// so that the heights still waited for are signaled once Core is back. It gives up once no height is waited for,
+// returning nil.
+func (w *heightWaiters) relisten(ctx context.Context) <-chan ctypes.ResultEvent {
+	w.lk.Lock()
+	// the subscription may be released meanwhile
+	if ctx.Err() != nil {
+		w.lk.Unlock()
+		return nil
+	}
+	// the ended subscription is released, keeping the waiters listening until a new one is made
+	err := w.release(context.Background())
+	if err != nil {
+		log.Errorw("unsubscribing from new blocks", "err", err)
+	}
+	w.release = func(context.Context) error { return nil }
+	w.stopIfIdle()
+	w.lk.Unlock()
+
+	backoff := resubscribeBackoff
+	for {
+		eventChan, release, err := w.subscribe(ctx)
+		if err == nil {
+			w.lk.Lock()
+			defer w.lk.Unlock()
+			// the waiters may be gone meanwhile, and stopping does not release the new subscription
+			if ctx.Err() != nil {
+				err = release(context.Background())
+				if err != nil {
+					log.Errorw("unsubscribing from new blocks", "err", err)
+				}
+				return nil
+			}
+			w.release = release
+			return eventChan
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Errorw("resubscribing to new blocks", "err", err, "retry_in", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil
+		}
+
+		backoff *= 2
+		if backoff > maxResubscribeBackoff {
+			backoff = maxResubscribeBackoff
+		}
+	}
+}
+
+// stopIfIdle releases the subscription once no height is waited for. It must be called under the lock.
+func (w *heightWaiters) stopIfIdle() {
+	if len(w.byHeight) > 0 || w.stop == nil {
+		return
+	}
+	w.stop()
+	w.stop = nil
+}
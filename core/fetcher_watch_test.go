@@ -0,0 +1,308 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+	"github.com/celestiaorg/celestia-node/service/block"
+)
+
+func TestBlockFetcher_WatchHeight(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	head := generateBlocks(t, fetcher, 2).Height
+
+	for _, height := range []int64{head, head + 2} {
+		availableCh, err := fetcher.WatchHeight(ctx, height)
+		require.NoError(t, err)
+		select {
+		case <-availableCh:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("height %d was not signaled", height)
+		}
+
+		exists, err := fetcher.BlockExists(ctx, height)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	}
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_WatchHeight_SharedSubscription(t *testing.T) {
+	const head = 10
+
+	eventChan := make(chan ctypes.ResultEvent, 2)
+	unsubscribed := make(chan struct{})
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().IsRunning().Return(true).AnyTimes()
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: head}}, nil).AnyTimes()
+	// all the watched heights share a single subscription, released once none is watched
+	client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(eventChan, nil).Times(1)
+	client.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, string, string) error {
+			close(unsubscribed)
+			return nil
+		}).Times(1)
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// available heights are signaled without subscribing
+	availableCh, err := fetcher.WatchHeight(ctx, head)
+	require.NoError(t, err)
+	select {
+	case <-availableCh:
+	default:
+		t.Fatal("available height was not signaled")
+	}
+
+	nextCh, err := fetcher.WatchHeight(ctx, head+1)
+	require.NoError(t, err)
+	laterCh, err := fetcher.WatchHeight(ctx, head+2)
+	require.NoError(t, err)
+
+	for i, waitCh := range []<-chan struct{}{nextCh, laterCh} {
+		eventChan <- ctypes.ResultEvent{
+			Data: types.EventDataNewBlock{Block: &types.Block{Header: types.Header{Height: head + int64(i) + 1}}},
+		}
+		select {
+		case <-waitCh:
+		case <-time.After(time.Second):
+			t.Fatalf("height %d was not signaled", head+i+1)
+		}
+	}
+
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not released")
+	}
+}
+
+func TestBlockFetcher_WatchHeight_SubscriptionEnded(t *testing.T) {
+	const head = 10
+
+	ended, resubscribed := make(chan ctypes.ResultEvent), make(chan ctypes.ResultEvent, 1)
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().IsRunning().Return(true).AnyTimes()
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: head}}, nil).AnyTimes()
+	// Core ends the first subscription and fails the first attempt to subscribe again
+	gomock.InOrder(
+		client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(ended, nil),
+		client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("unavailable")),
+		client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(resubscribed, nil),
+	)
+	client.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	availableCh, err := fetcher.WatchHeight(ctx, head+1)
+	require.NoError(t, err)
+	close(ended)
+
+	resubscribed <- ctypes.ResultEvent{
+		Data: types.EventDataNewBlock{Block: &types.Block{Header: types.Header{Height: head + 1}}},
+	}
+	select {
+	case <-availableCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("height was not signaled after resubscribing")
+	}
+}
+
+func TestBlockFetcher_WatchHeight_HungSubscribe(t *testing.T) {
+	const head = 10
+
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().IsRunning().Return(true).AnyTimes()
+	client.EXPECT().Status(gomock.Any()).
+		Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: head}}, nil).AnyTimes()
+	client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _, _ string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	t.Cleanup(cancel)
+
+	// the hung subscription is given up with the context rather than after the subscription timeout
+	start := time.Now()
+	_, err := fetcher.WatchHeight(ctx, head+1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), fetcher.subs.timeout/2)
+}
+
+func TestBlockFetcher_WatchHeights_Remote(t *testing.T) {
+	fetcher := startRemoteFetcher(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	// remote Core keys subscriptions by query, so watching heights must not take over SubscribeNewBlockEvent's events
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	head, err := fetcher.head(ctx)
+	require.NoError(t, err)
+	var delivered []int64
+	for height := range fetcher.WatchHeights(ctx, []int64{head + 2, head, head + 1}) {
+		delivered = append(delivered, height)
+	}
+	require.NoError(t, ctx.Err())
+	assert.Equal(t, []int64{head, head + 1, head + 2}, delivered)
+
+	// the remote node produces blocks faster than they are read, so some of them may be dropped
+	receive := func() *block.RawBlock {
+		select {
+		case raw, ok := <-newBlockChan:
+			require.True(t, ok)
+			return raw
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription starved")
+			return nil
+		}
+	}
+	prev := receive()
+	for i := 0; i < 3; i++ {
+		raw := receive()
+		assert.Greater(t, raw.Height, prev.Height)
+		prev = raw
+	}
+
+	cancel()
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(context.Background()))
+}
+
+func TestBlockFetcher_WatchHeights(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	head := generateBlocks(t, fetcher, 2).Height
+
+	var delivered []int64
+	for height := range fetcher.WatchHeights(ctx, []int64{head + 2, 1, head + 1, 1}) {
+		delivered = append(delivered, height)
+	}
+	require.NoError(t, ctx.Err())
+	assert.Equal(t, []int64{1, head + 1, head + 2}, delivered)
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_NotifyOnHeight(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	head := generateBlocks(t, fetcher, 2).Height
+
+	var tests = []struct {
+		name   string
+		height int64
+	}{
+		{name: "past", height: 1},
+		{name: "current", height: head},
+		{name: "future", height: head + 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockCh, err := fetcher.NotifyOnHeight(ctx, tt.height)
+			require.NoError(t, err)
+			raw, ok := <-blockCh
+			require.True(t, ok)
+			assert.Equal(t, tt.height, raw.Height)
+			// exactly one block is delivered
+			_, ok = <-blockCh
+			assert.False(t, ok)
+		})
+	}
+
+	// concurrent notifications for different heights do not interfere
+	heights := []int64{head + 4, head + 3, head + 5}
+	blockChs := make([]<-chan *block.RawBlock, len(heights))
+	for i, height := range heights {
+		var err error
+		blockChs[i], err = fetcher.NotifyOnHeight(ctx, height)
+		require.NoError(t, err)
+	}
+	for i, blockCh := range blockChs {
+		raw, ok := <-blockCh
+		require.True(t, ok)
+		assert.Equal(t, heights[i], raw.Height)
+	}
+
+	require.NoError(t, client.Stop())
+}
+
+func TestBlockFetcher_NotifyOnHeight_Remote(t *testing.T) {
+	fetcher := startRemoteFetcher(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	// remote Core keys subscriptions by query, so notifications must not take over SubscribeNewBlockEvent's events
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	// the remote node produces blocks faster than they are read, so some of them may be dropped
+	receive := func() *block.RawBlock {
+		select {
+		case raw, ok := <-newBlockChan:
+			require.True(t, ok)
+			return raw
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription starved")
+			return nil
+		}
+	}
+	// the head is zero until the first block is produced
+	receive()
+	head, err := fetcher.head(ctx)
+	require.NoError(t, err)
+	for _, height := range []int64{head, head + 3} {
+		blockCh, err := fetcher.NotifyOnHeight(ctx, height)
+		require.NoError(t, err)
+		// the remote node prunes blocks fast, so the notified block may be gone before it is fetched
+		for raw := range blockCh {
+			assert.Equal(t, height, raw.Height)
+		}
+	}
+
+	prev := receive()
+	for i := 0; i < 3; i++ {
+		raw := receive()
+		assert.Greater(t, raw.Height, prev.Height)
+		prev = raw
+	}
+
+	cancel()
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(context.Background()))
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// heartbeatFailures is the amount of consecutive failed heartbeats after which the connection to Core is stale.
+const heartbeatFailures = 3
+
+// errHeartbeatInterval is returned by StartHeartbeat on non-positive interval.
+var errHeartbeatInterval = errors.New("core: heartbeat interval must be positive")
+
+// health tracks whether the connection to Core is alive. It is shared by reference, so copies of the
+// BlockFetcher made by middlewares report the same health.
+type health struct {
+	stale int32
+	// reconnect signals the new block subscription to be re-established.
+	reconnect chan struct{}
+}
+
+func newHealth() *health {
+	return &health{reconnect: make(chan struct{}, 1)}
+}
+
+// WithPingTimeout limits the time given to Core to respond to each heartbeat ping.
+// By default, a ping is given the whole heartbeat interval.
+func WithPingTimeout(timeout time.Duration) Option {
+	return func(f *BlockFetcher) {
+		f.pingTimeout = timeout
+	}
+}
+
+// StartHeartbeat periodically pings Core in the background until the context is canceled, so that a half-open
+// connection silently dropping requests is detected. After a few consecutive failed pings the connection is marked
+// stale and the new block subscription, if any, is re-established.
+func (f *BlockFetcher) StartHeartbeat(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return errHeartbeatInterval
+	}
+
+	timeout := f.pingTimeout
+	if timeout <= 0 {
+		timeout = interval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var failures int
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			pingCtx, cancel := context.WithTimeout(ctx, timeout)
+			err := f.client.Ping(pingCtx)
+			cancel()
+			if err == nil {
+				failures = 0
+				if atomic.CompareAndSwapInt32(&f.health.stale, 1, 0) {
+					log.Infow("connection to Core recovered")
+				}
+				continue
+			}
+
+			failures++
+			if failures == heartbeatFailures && atomic.CompareAndSwapInt32(&f.health.stale, 0, 1) {
+				log.Warnw("connection to Core is stale", "failures", failures, "err", err)
+				select {
+				case f.health.reconnect <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// IsHealthy reports whether the connection to Core is alive, as seen by the heartbeat.
+// Without the heartbeat started, the connection is always considered healthy.
+func (f *BlockFetcher) IsHealthy() bool {
+	return atomic.LoadInt32(&f.health.stale) == 0
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_Heartbeat(t *testing.T) {
+	var pings, recovered int32
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Ping(gomock.Any()).DoAndReturn(func(context.Context) error {
+		// the first 3 pings succeed, the rest fail until recovered
+		if atomic.AddInt32(&pings, 1) > 3 && atomic.LoadInt32(&recovered) == 0 {
+			return errors.New("i/o timeout")
+		}
+		return nil
+	}).AnyTimes()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	assert.Error(t, fetcher.StartHeartbeat(ctx, 0))
+	require.NoError(t, fetcher.StartHeartbeat(ctx, 5*time.Millisecond))
+	assert.True(t, fetcher.IsHealthy())
+
+	assert.Eventually(t, func() bool {
+		return !fetcher.IsHealthy()
+	}, time.Second, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&pings), int32(3+heartbeatFailures))
+	// the new block subscription is asked to reconnect
+	assert.Len(t, fetcher.health.reconnect, 1)
+
+	atomic.StoreInt32(&recovered, 1)
+	assert.Eventually(t, fetcher.IsHealthy, time.Second, 5*time.Millisecond)
+}
+
+func TestBlockFetcher_Heartbeat_PingTimeout(t *testing.T) {
+	const pingTimeout = 5 * time.Millisecond
+
+	budgets := make(chan time.Duration, 1)
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Ping(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		select {
+		case budgets <- time.Until(deadline):
+		default:
+		}
+		return nil
+	}).AnyTimes()
+	fetcher := NewBlockFetcher(client, WithPingTimeout(pingTimeout))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, fetcher.StartHeartbeat(ctx, 10*time.Millisecond))
+	select {
+	case budget := <-budgets:
+		// each ping is given the ping timeout rather than the whole interval
+		assert.LessOrEqual(t, budget, pingTimeout)
+	case <-time.After(time.Second):
+		t.Fatal("heartbeat did not ping")
+	}
+}
+
+func TestBlockFetcher_Heartbeat_StaleBeforeSubscribing(t *testing.T) {
+	eventChan := make(chan ctypes.ResultEvent, 1)
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().IsRunning().Return(true).AnyTimes()
+	// the subscription is made once and never renewed
+	client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(eventChan, nil).Times(1)
+	client.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	fetcher := NewBlockFetcher(client)
+
+	// the connection went stale while nothing was listening
+	fetcher.health.reconnect <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	eventChan <- ctypes.ResultEvent{
+		Data: types.EventDataNewBlock{Block: &types.Block{Header: types.Header{Height: 1}}},
+	}
+	select {
+	case b := <-newBlockChan:
+		assert.EqualValues(t, 1, b.Height)
+	case <-time.After(time.Second):
+		t.Fatal("new block was not delivered")
+	}
+	assert.Len(t, fetcher.health.reconnect, 0)
+
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(context.Background()))
+}
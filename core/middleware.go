@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+)
+
+// BlockFetcherMiddleware customizes the behaviour of a BlockFetcher by wrapping it.
+type BlockFetcherMiddleware interface {
+	Wrap(*BlockFetcher) *BlockFetcher
+}
+
+// BlockFetcherMiddlewareFunc is an adapter allowing to use ordinary functions as BlockFetcherMiddleware.
+type BlockFetcherMiddlewareFunc func(*BlockFetcher) *BlockFetcher
+
+func (fn BlockFetcherMiddlewareFunc) Wrap(f *BlockFetcher) *BlockFetcher {
+	return fn(f)
+}
+
+// ApplyMiddlewares composes the given middlewares over the BlockFetcher.
+// The first middleware is the outermost one, so it is the first to see each request.
+func ApplyMiddlewares(fetcher *BlockFetcher, middlewares ...BlockFetcherMiddleware) *BlockFetcher {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		fetcher = middlewares[i].Wrap(fetcher)
+	}
+	return fetcher
+}
+
+// withClient returns a copy of the BlockFetcher talking to Core through the given Client.
+// The copy shares settings, caches and subscriptions to Core with the BlockFetcher, but subscribes to new block
+// events on its own.
+func (f *BlockFetcher) withClient(client Client) *BlockFetcher {
+	cp := *f
+	cp.client = client
+	cp.newBlockCh, cp.releaseNewBlocks = nil, nil
+	return &cp
+}
+
+// LoggingMiddleware logs every block request BlockFetcher makes to Core.
+type LoggingMiddleware struct{}
+
+func (LoggingMiddleware) Wrap(f *BlockFetcher) *BlockFetcher {
+	return f.withClient(&loggingClient{f.client})
+}
+
+type loggingClient struct {
+	Client
+}
+
+func (c *loggingClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	start := time.Now()
+	res, err := c.Client.Block(ctx, height)
+	if err != nil {
+		log.Debugw("requesting block", "height", heightOf(height), "elapsed", time.Since(start), "err", err)
+		return nil, err
+	}
+	log.Debugw("requested block", "height", res.Block.Height, "elapsed", time.Since(start))
+	return res, nil
+}
+
+// MetricsMiddleware counts block requests BlockFetcher makes to Core.
+type MetricsMiddleware struct {
+	requests, failures uint64
+}
+
+func (m *MetricsMiddleware) Wrap(f *BlockFetcher) *BlockFetcher {
+	return f.withClient(&metricsClient{Client: f.client, metrics: m})
+}
+
+// Requests reports the total amount of block requests made.
+func (m *MetricsMiddleware) Requests() uint64 {
+	return atomic.LoadUint64(&m.requests)
+}
+
+// Failures reports the amount of failed block requests.
+func (m *MetricsMiddleware) Failures() uint64 {
+	return atomic.LoadUint64(&m.failures)
+}
+
+type metricsClient struct {
+	Client
+	metrics *MetricsMiddleware
+}
+
+func (c *metricsClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	atomic.AddUint64(&c.metrics.requests, 1)
+	res, err := c.Client.Block(ctx, height)
+	if err != nil {
+		atomic.AddUint64(&c.metrics.failures, 1)
+	}
+	return res, err
+}
+
+// RetryMiddleware retries failed block requests up to Attempts times in total, waiting for Backoff in between.
+type RetryMiddleware struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+func (m RetryMiddleware) Wrap(f *BlockFetcher) *BlockFetcher {
+	return f.withClient(&retryClient{Client: f.client, cfg: m})
+}
+
+type retryClient struct {
+	Client
+	cfg RetryMiddleware
+}
+
+func (c *retryClient) Block(ctx context.Context, height *int64) (res *ctypes.ResultBlock, err error) {
+	for attempt := 1; ; attempt++ {
+		recordAttempt(ctx, attempt)
+		res, err = c.Client.Block(ctx, height)
+		if err == nil || attempt >= c.cfg.Attempts {
+			return res, err
+		}
+
+		log.Debugw("retrying block request", "height", heightOf(height), "attempt", attempt, "err", err)
+		select {
+		case <-time.After(c.cfg.Backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
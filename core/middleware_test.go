@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestApplyMiddlewares_Order(t *testing.T) {
+	var calls []string
+	record := func(name string) BlockFetcherMiddleware {
+		return BlockFetcherMiddlewareFunc(func(f *BlockFetcher) *BlockFetcher {
+			next := f.client
+			client := mocks.NewMockClient(gomock.NewController(t))
+			client.EXPECT().Block(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+					calls = append(calls, name)
+					return next.Block(ctx, height)
+				}).AnyTimes()
+			return f.withClient(client)
+		})
+	}
+
+	fetcher := ApplyMiddlewares(newMockBlockFetcher(t, 0), record("first"), record("second"), record("third"))
+	_, err := fetcher.GetBlock(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "third"}, calls)
+}
+
+func TestApplyMiddlewares_NoOp(t *testing.T) {
+	noop := BlockFetcherMiddlewareFunc(func(f *BlockFetcher) *BlockFetcher {
+		return f
+	})
+
+	fetcher := newMockBlockFetcher(t, 0)
+	assert.Same(t, fetcher, ApplyMiddlewares(fetcher, noop, noop))
+	assert.Same(t, fetcher, ApplyMiddlewares(fetcher))
+}
+
+func TestBuiltinMiddlewares(t *testing.T) {
+	metrics := &MetricsMiddleware{}
+	// the metrics middleware is the innermost, so it sees every retry
+	fetcher := ApplyMiddlewares(newMockBlockFetcher(t, 2), LoggingMiddleware{}, RetryMiddleware{Attempts: 3}, metrics)
+
+	height := int64(1)
+	b, err := fetcher.GetBlock(context.Background(), &height)
+	require.NoError(t, err)
+	assert.Equal(t, height, b.Height)
+	assert.EqualValues(t, 3, metrics.Requests())
+	assert.EqualValues(t, 2, metrics.Failures())
+}
+
+func TestApplyMiddlewares_Subscribed(t *testing.T) {
+	client := MockEmbeddedClient()
+	fetcher := NewBlockFetcher(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	// the wrapping BlockFetcher has a subscription of its own, which does not end the wrapped one
+	wrapped := ApplyMiddlewares(fetcher, LoggingMiddleware{})
+	_, err = wrapped.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+	require.NoError(t, wrapped.UnsubscribeNewBlockEvent(ctx))
+	assert.ErrorIs(t, wrapped.UnsubscribeNewBlockEvent(ctx), ErrNoSubscription)
+
+	select {
+	case raw := <-newBlockChan:
+		assert.NotNil(t, raw)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription of the wrapped BlockFetcher ended")
+	}
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
+
+	// settings changed after wrapping apply to both
+	fetcher.SetDefaultTimeout(time.Minute)
+	assert.Equal(t, time.Minute, wrapped.defaultTimeout)
+
+	require.NoError(t, client.Stop())
+}
+
+// newMockBlockFetcher creates a BlockFetcher over a mock Client failing the first 'failures' block requests.
+func newMockBlockFetcher(t *testing.T, failures int) *BlockFetcher {
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Status(gomock.Any()).DoAndReturn(func(context.Context) (*ctypes.ResultStatus, error) {
+		return &ctypes.ResultStatus{}, nil
+	}).AnyTimes()
+	client.EXPECT().Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+			if failures > 0 {
+				failures--
+				return nil, errors.New("unavailable")
+			}
+			return &ctypes.ResultBlock{Block: &types.Block{Header: types.Header{Height: heightOf(height)}}}, nil
+		}).AnyTimes()
+	return NewBlockFetcher(client)
+}
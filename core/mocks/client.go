@@ -0,0 +1,628 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/celestiaorg/celestia-node/core (interfaces: Client)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	bytes "github.com/celestiaorg/celestia-core/libs/bytes"
+	log "github.com/celestiaorg/celestia-core/libs/log"
+	client "github.com/celestiaorg/celestia-core/rpc/client"
+	coretypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	types "github.com/celestiaorg/celestia-core/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// ABCIInfo mocks base method.
+func (m *MockClient) ABCIInfo(arg0 context.Context) (*coretypes.ResultABCIInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABCIInfo", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultABCIInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABCIInfo indicates an expected call of ABCIInfo.
+func (mr *MockClientMockRecorder) ABCIInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABCIInfo", reflect.TypeOf((*MockClient)(nil).ABCIInfo), arg0)
+}
+
+// ABCIQuery mocks base method.
+func (m *MockClient) ABCIQuery(arg0 context.Context, arg1 string, arg2 bytes.HexBytes) (*coretypes.ResultABCIQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABCIQuery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*coretypes.ResultABCIQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABCIQuery indicates an expected call of ABCIQuery.
+func (mr *MockClientMockRecorder) ABCIQuery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABCIQuery", reflect.TypeOf((*MockClient)(nil).ABCIQuery), arg0, arg1, arg2)
+}
+
+// ABCIQueryWithOptions mocks base method.
+func (m *MockClient) ABCIQueryWithOptions(arg0 context.Context, arg1 string, arg2 bytes.HexBytes, arg3 client.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABCIQueryWithOptions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*coretypes.ResultABCIQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABCIQueryWithOptions indicates an expected call of ABCIQueryWithOptions.
+func (mr *MockClientMockRecorder) ABCIQueryWithOptions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABCIQueryWithOptions", reflect.TypeOf((*MockClient)(nil).ABCIQueryWithOptions), arg0, arg1, arg2, arg3)
+}
+
+// Block mocks base method.
+func (m *MockClient) Block(arg0 context.Context, arg1 *int64) (*coretypes.ResultBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockClientMockRecorder) Block(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockClient)(nil).Block), arg0, arg1)
+}
+
+// BlockByHash mocks base method.
+func (m *MockClient) BlockByHash(arg0 context.Context, arg1 []byte) (*coretypes.ResultBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockByHash", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockByHash indicates an expected call of BlockByHash.
+func (mr *MockClientMockRecorder) BlockByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockByHash", reflect.TypeOf((*MockClient)(nil).BlockByHash), arg0, arg1)
+}
+
+// BlockResults mocks base method.
+func (m *MockClient) BlockResults(arg0 context.Context, arg1 *int64) (*coretypes.ResultBlockResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockResults", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultBlockResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockResults indicates an expected call of BlockResults.
+func (mr *MockClientMockRecorder) BlockResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockResults", reflect.TypeOf((*MockClient)(nil).BlockResults), arg0, arg1)
+}
+
+// BlockchainInfo mocks base method.
+func (m *MockClient) BlockchainInfo(arg0 context.Context, arg1, arg2 int64) (*coretypes.ResultBlockchainInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockchainInfo", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*coretypes.ResultBlockchainInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockchainInfo indicates an expected call of BlockchainInfo.
+func (mr *MockClientMockRecorder) BlockchainInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockchainInfo", reflect.TypeOf((*MockClient)(nil).BlockchainInfo), arg0, arg1, arg2)
+}
+
+// BroadcastEvidence mocks base method.
+func (m *MockClient) BroadcastEvidence(arg0 context.Context, arg1 types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BroadcastEvidence", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultBroadcastEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BroadcastEvidence indicates an expected call of BroadcastEvidence.
+func (mr *MockClientMockRecorder) BroadcastEvidence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastEvidence", reflect.TypeOf((*MockClient)(nil).BroadcastEvidence), arg0, arg1)
+}
+
+// BroadcastTxAsync mocks base method.
+func (m *MockClient) BroadcastTxAsync(arg0 context.Context, arg1 types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BroadcastTxAsync", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultBroadcastTx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BroadcastTxAsync indicates an expected call of BroadcastTxAsync.
+func (mr *MockClientMockRecorder) BroadcastTxAsync(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastTxAsync", reflect.TypeOf((*MockClient)(nil).BroadcastTxAsync), arg0, arg1)
+}
+
+// BroadcastTxCommit mocks base method.
+func (m *MockClient) BroadcastTxCommit(arg0 context.Context, arg1 types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BroadcastTxCommit", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultBroadcastTxCommit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BroadcastTxCommit indicates an expected call of BroadcastTxCommit.
+func (mr *MockClientMockRecorder) BroadcastTxCommit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastTxCommit", reflect.TypeOf((*MockClient)(nil).BroadcastTxCommit), arg0, arg1)
+}
+
+// BroadcastTxSync mocks base method.
+func (m *MockClient) BroadcastTxSync(arg0 context.Context, arg1 types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BroadcastTxSync", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultBroadcastTx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BroadcastTxSync indicates an expected call of BroadcastTxSync.
+func (mr *MockClientMockRecorder) BroadcastTxSync(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastTxSync", reflect.TypeOf((*MockClient)(nil).BroadcastTxSync), arg0, arg1)
+}
+
+// CheckTx mocks base method.
+func (m *MockClient) CheckTx(arg0 context.Context, arg1 types.Tx) (*coretypes.ResultCheckTx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTx", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultCheckTx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckTx indicates an expected call of CheckTx.
+func (mr *MockClientMockRecorder) CheckTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTx", reflect.TypeOf((*MockClient)(nil).CheckTx), arg0, arg1)
+}
+
+// Commit mocks base method.
+func (m *MockClient) Commit(arg0 context.Context, arg1 *int64) (*coretypes.ResultCommit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultCommit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockClientMockRecorder) Commit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockClient)(nil).Commit), arg0, arg1)
+}
+
+// ConsensusParams mocks base method.
+func (m *MockClient) ConsensusParams(arg0 context.Context, arg1 *int64) (*coretypes.ResultConsensusParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsensusParams", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultConsensusParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsensusParams indicates an expected call of ConsensusParams.
+func (mr *MockClientMockRecorder) ConsensusParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsensusParams", reflect.TypeOf((*MockClient)(nil).ConsensusParams), arg0, arg1)
+}
+
+// ConsensusState mocks base method.
+func (m *MockClient) ConsensusState(arg0 context.Context) (*coretypes.ResultConsensusState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsensusState", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultConsensusState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsensusState indicates an expected call of ConsensusState.
+func (mr *MockClientMockRecorder) ConsensusState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsensusState", reflect.TypeOf((*MockClient)(nil).ConsensusState), arg0)
+}
+
+// DataAvailabilityHeader mocks base method.
+func (m *MockClient) DataAvailabilityHeader(arg0 context.Context, arg1 *int64) (*coretypes.ResultDataAvailabilityHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataAvailabilityHeader", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultDataAvailabilityHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DataAvailabilityHeader indicates an expected call of DataAvailabilityHeader.
+func (mr *MockClientMockRecorder) DataAvailabilityHeader(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataAvailabilityHeader", reflect.TypeOf((*MockClient)(nil).DataAvailabilityHeader), arg0, arg1)
+}
+
+// DumpConsensusState mocks base method.
+func (m *MockClient) DumpConsensusState(arg0 context.Context) (*coretypes.ResultDumpConsensusState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpConsensusState", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultDumpConsensusState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpConsensusState indicates an expected call of DumpConsensusState.
+func (mr *MockClientMockRecorder) DumpConsensusState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpConsensusState", reflect.TypeOf((*MockClient)(nil).DumpConsensusState), arg0)
+}
+
+// Genesis mocks base method.
+func (m *MockClient) Genesis(arg0 context.Context) (*coretypes.ResultGenesis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Genesis", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultGenesis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Genesis indicates an expected call of Genesis.
+func (mr *MockClientMockRecorder) Genesis(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Genesis", reflect.TypeOf((*MockClient)(nil).Genesis), arg0)
+}
+
+// Health mocks base method.
+func (m *MockClient) Health(arg0 context.Context) (*coretypes.ResultHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockClientMockRecorder) Health(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockClient)(nil).Health), arg0)
+}
+
+// IsRunning mocks base method.
+func (m *MockClient) IsRunning() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRunning")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsRunning indicates an expected call of IsRunning.
+func (mr *MockClientMockRecorder) IsRunning() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRunning", reflect.TypeOf((*MockClient)(nil).IsRunning))
+}
+
+// NetInfo mocks base method.
+func (m *MockClient) NetInfo(arg0 context.Context) (*coretypes.ResultNetInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetInfo", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultNetInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetInfo indicates an expected call of NetInfo.
+func (mr *MockClientMockRecorder) NetInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetInfo", reflect.TypeOf((*MockClient)(nil).NetInfo), arg0)
+}
+
+// NumUnconfirmedTxs mocks base method.
+func (m *MockClient) NumUnconfirmedTxs(arg0 context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NumUnconfirmedTxs", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultUnconfirmedTxs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NumUnconfirmedTxs indicates an expected call of NumUnconfirmedTxs.
+func (mr *MockClientMockRecorder) NumUnconfirmedTxs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumUnconfirmedTxs", reflect.TypeOf((*MockClient)(nil).NumUnconfirmedTxs), arg0)
+}
+
+// OnReset mocks base method.
+func (m *MockClient) OnReset() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnReset")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnReset indicates an expected call of OnReset.
+func (mr *MockClientMockRecorder) OnReset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnReset", reflect.TypeOf((*MockClient)(nil).OnReset))
+}
+
+// OnStart mocks base method.
+func (m *MockClient) OnStart() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnStart")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnStart indicates an expected call of OnStart.
+func (mr *MockClientMockRecorder) OnStart() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnStart", reflect.TypeOf((*MockClient)(nil).OnStart))
+}
+
+// OnStop mocks base method.
+func (m *MockClient) OnStop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnStop")
+}
+
+// OnStop indicates an expected call of OnStop.
+func (mr *MockClientMockRecorder) OnStop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnStop", reflect.TypeOf((*MockClient)(nil).OnStop))
+}
+
+// Ping mocks base method.
+func (m *MockClient) Ping(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockClientMockRecorder) Ping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockClient)(nil).Ping), arg0)
+}
+
+// Quit mocks base method.
+func (m *MockClient) Quit() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quit")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Quit indicates an expected call of Quit.
+func (mr *MockClientMockRecorder) Quit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quit", reflect.TypeOf((*MockClient)(nil).Quit))
+}
+
+// Reset mocks base method.
+func (m *MockClient) Reset() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockClientMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockClient)(nil).Reset))
+}
+
+// SetLogger mocks base method.
+func (m *MockClient) SetLogger(arg0 log.Logger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLogger", arg0)
+}
+
+// SetLogger indicates an expected call of SetLogger.
+func (mr *MockClientMockRecorder) SetLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogger", reflect.TypeOf((*MockClient)(nil).SetLogger), arg0)
+}
+
+// Start mocks base method.
+func (m *MockClient) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockClientMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockClient)(nil).Start))
+}
+
+// Status mocks base method.
+func (m *MockClient) Status(arg0 context.Context) (*coretypes.ResultStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(*coretypes.ResultStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockClientMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockClient)(nil).Status), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockClient) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockClientMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClient)(nil).Stop))
+}
+
+// String mocks base method.
+func (m *MockClient) String() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "String")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// String indicates an expected call of String.
+func (mr *MockClientMockRecorder) String() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockClient)(nil).String))
+}
+
+// Subscribe mocks base method.
+func (m *MockClient) Subscribe(arg0 context.Context, arg1, arg2 string, arg3 ...int) (<-chan coretypes.ResultEvent, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Subscribe", varargs...)
+	ret0, _ := ret[0].(<-chan coretypes.ResultEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockClientMockRecorder) Subscribe(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockClient)(nil).Subscribe), varargs...)
+}
+
+// Tx mocks base method.
+func (m *MockClient) Tx(arg0 context.Context, arg1 []byte, arg2 bool) (*coretypes.ResultTx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*coretypes.ResultTx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tx indicates an expected call of Tx.
+func (mr *MockClientMockRecorder) Tx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tx", reflect.TypeOf((*MockClient)(nil).Tx), arg0, arg1, arg2)
+}
+
+// TxSearch mocks base method.
+func (m *MockClient) TxSearch(arg0 context.Context, arg1 string, arg2 bool, arg3, arg4 *int, arg5 string) (*coretypes.ResultTxSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxSearch", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*coretypes.ResultTxSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxSearch indicates an expected call of TxSearch.
+func (mr *MockClientMockRecorder) TxSearch(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxSearch", reflect.TypeOf((*MockClient)(nil).TxSearch), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// UnconfirmedTxs mocks base method.
+func (m *MockClient) UnconfirmedTxs(arg0 context.Context, arg1 *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnconfirmedTxs", arg0, arg1)
+	ret0, _ := ret[0].(*coretypes.ResultUnconfirmedTxs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnconfirmedTxs indicates an expected call of UnconfirmedTxs.
+func (mr *MockClientMockRecorder) UnconfirmedTxs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnconfirmedTxs", reflect.TypeOf((*MockClient)(nil).UnconfirmedTxs), arg0, arg1)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockClient) Unsubscribe(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockClientMockRecorder) Unsubscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockClient)(nil).Unsubscribe), arg0, arg1, arg2)
+}
+
+// UnsubscribeAll mocks base method.
+func (m *MockClient) UnsubscribeAll(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsubscribeAll indicates an expected call of UnsubscribeAll.
+func (mr *MockClientMockRecorder) UnsubscribeAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeAll", reflect.TypeOf((*MockClient)(nil).UnsubscribeAll), arg0, arg1)
+}
+
+// Validators mocks base method.
+func (m *MockClient) Validators(arg0 context.Context, arg1 *int64, arg2, arg3 *int) (*coretypes.ResultValidators, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validators", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*coretypes.ResultValidators)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validators indicates an expected call of Validators.
+func (mr *MockClientMockRecorder) Validators(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validators", reflect.TypeOf((*MockClient)(nil).Validators), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,315 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+)
+
+// subscriptionBuffer is the amount of events buffered for the Core subscription and for each of its consumers, so
+// that bursts of events are not dropped.
+const subscriptionBuffer = 16
+
+// subscriptionTimeout bounds every call subscriptions make to Core, so that an unresponsive Core never holds up
+// subscribing to or releasing the query for good.
+const subscriptionTimeout = 10 * time.Second
+
+// subscriptions shares a single Core subscription per query between any amount of consumers.
+// Remote Core clients key subscriptions by query and ignore the subscriber name, so two subscriptions to the same
+// query would take over each other's events, and unsubscribing one of them would end both.
+// Calls to Core are made outside of the lock, one at a time per query, so that they never race with each other
+// and a slow call only holds up its own query.
+type subscriptions struct {
+	client  Client
+	timeout time.Duration
+
+	lk      sync.Mutex
+	byQuery map[string]*subscription
+	// inflight is closed once the call to Core made for the query completes
+	inflight map[string]chan struct{}
+	lastID   uint64
+}
+
+// subscription is a Core subscription to a query whose events are fanned out to all of its consumers.
+type subscription struct {
+	subscriber, query string
+	consumers         map[uint64]*consumer
+	cancel            context.CancelFunc
+	// renewals signals the fan out to re-establish the Core subscription
+	renewals chan struct{}
+}
+
+type consumer struct {
+	out  chan ctypes.ResultEvent
+	once sync.Once
+}
+
+func newSubscriptions(client Client) *subscriptions {
+	return &subscriptions{
+		client:   client,
+		timeout:  subscriptionTimeout,
+		byQuery:  make(map[string]*subscription),
+		inflight: make(map[string]chan struct{}),
+	}
+}
+
+// subscribe returns events of the query, subscribing to Core only if no one is subscribed to the query yet.
+// The returned channel is closed if Core ends the subscription. The returned function releases the events and
+// unsubscribes from Core once no one else consumes them.
+func (s *subscriptions) subscribe(
+	ctx context.Context,
+	subscriber, query string,
+) (<-chan ctypes.ResultEvent, func(context.Context) error, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	for {
+		if sub, ok := s.byQuery[query]; ok {
+			out, release := s.consume(sub)
+			return out, release, nil
+		}
+		if _, ok := s.inflight[query]; !ok {
+			break
+		}
+		if err := s.await(ctx, query); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	done := s.begin(query)
+	s.lk.Unlock()
+	callCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	eventChan, err := s.client.Subscribe(callCtx, subscriber, query, subscriptionBuffer)
+	cancel()
+	s.lk.Lock()
+	done()
+	if err != nil {
+		return nil, nil, &StreamError{Op: "subscribe", Err: err}
+	}
+
+	fanOutCtx, cancelFanOut := context.WithCancel(context.Background())
+	sub := &subscription{
+		subscriber: subscriber,
+		query:      query,
+		consumers:  make(map[uint64]*consumer),
+		cancel:     cancelFanOut,
+		renewals:   make(chan struct{}, 1),
+	}
+	s.byQuery[query] = sub
+	go s.fanOut(fanOutCtx, sub, eventChan)
+
+	out, release := s.consume(sub)
+	return out, release, nil
+}
+
+// consume adds a new consumer to the subscription. It must be called under the lock.
+func (s *subscriptions) consume(sub *subscription) (<-chan ctypes.ResultEvent, func(context.Context) error) {
+	s.lastID++
+	id, c := s.lastID, &consumer{out: make(chan ctypes.ResultEvent, subscriptionBuffer)}
+	sub.consumers[id] = c
+	return c.out, func(ctx context.Context) (err error) {
+		c.once.Do(func() {
+			err = s.release(ctx, sub, id)
+		})
+		return err
+	}
+}
+
+// release removes the consumer from the subscription and unsubscribes from Core if it was the last one.
+func (s *subscriptions) release(ctx context.Context, sub *subscription, id uint64) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	delete(sub.consumers, id)
+	if len(sub.consumers) > 0 || s.byQuery[sub.query] != sub {
+		return nil
+	}
+
+	delete(s.byQuery, sub.query)
+	sub.cancel()
+	// calls in flight are bounded by the timeout, and unsubscribing must follow them, so that it ends whatever
+	// subscription they made
+	for {
+		if _, ok := s.inflight[sub.query]; !ok {
+			break
+		}
+		s.await(context.Background(), sub.query) //nolint:errcheck
+	}
+
+	done := s.begin(sub.query)
+	s.lk.Unlock()
+	callCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	err := s.client.Unsubscribe(callCtx, sub.subscriber, sub.query)
+	cancel()
+	s.lk.Lock()
+	done()
+	if err != nil {
+		return &StreamError{Op: "unsubscribe", Err: err}
+	}
+	return nil
+}
+
+// begin marks a call to Core in flight for the query. The returned function marks it completed.
+// Both must be called under the lock.
+func (s *subscriptions) begin(query string) func() {
+	inflight := make(chan struct{})
+	s.inflight[query] = inflight
+	return func() {
+		delete(s.inflight, query)
+		close(inflight)
+	}
+}
+
+// await waits for the call to Core in flight for the query to complete, or for the context to be done.
+// It must be called under the lock, which is released while waiting.
+func (s *subscriptions) await(ctx context.Context, query string) error {
+	inflight, ok := s.inflight[query]
+	if !ok {
+		return nil
+	}
+
+	s.lk.Unlock()
+	defer s.lk.Lock()
+	select {
+	case <-inflight:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// renew re-establishes the Core subscription to the query in the background, keeping its consumers.
+// It is used when the subscription is suspected to be silently dead.
+func (s *subscriptions) renew(query string) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	sub, ok := s.byQuery[query]
+	if !ok {
+		return
+	}
+	select {
+	case sub.renewals <- struct{}{}:
+	default:
+	}
+}
+
+// fanOut delivers every event of the Core subscription to all of the consumers.
+// Like remote Core clients do, events are dropped for consumers whose buffer is full, so that a slow consumer never
+// holds back the others.
+func (s *subscriptions) fanOut(ctx context.Context, sub *subscription, eventChan <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.renewals:
+			eventChan = s.resubscribe(ctx, sub)
+			if eventChan == nil {
+				return
+			}
+		case event, ok := <-eventChan:
+			if !ok {
+				s.end(sub)
+				return
+			}
+
+			for _, c := range s.consumersOf(sub) {
+				select {
+				case c.out <- event:
+				default:
+					log.Warnw("dropping event for slow consumer", "query", sub.query)
+				}
+			}
+		}
+	}
+}
+
+// resubscribe re-establishes the Core subscription, backing off exponentially on failures.
+// It only gives up once the subscription is released, returning nil.
+func (s *subscriptions) resubscribe(ctx context.Context, sub *subscription) <-chan ctypes.ResultEvent {
+	backoff := resubscribeBackoff
+	for {
+		eventChan, err := s.redo(ctx, sub)
+		if err == nil {
+			return eventChan
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Errorw("resubscribing", "query", sub.query, "err", err, "retry_in", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil
+		}
+
+		backoff *= 2
+		if backoff > maxResubscribeBackoff {
+			backoff = maxResubscribeBackoff
+		}
+	}
+}
+
+// redo replaces the Core subscription with a new one, unless the subscription is released meanwhile.
+func (s *subscriptions) redo(ctx context.Context, sub *subscription) (<-chan ctypes.ResultEvent, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if _, ok := s.inflight[sub.query]; !ok {
+			break
+		}
+		if err := s.await(ctx, sub.query); err != nil {
+			return nil, err
+		}
+	}
+
+	done := s.begin(sub.query)
+	s.lk.Unlock()
+	callCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	// the old subscription is likely dead already, so the error is not interesting
+	s.client.Unsubscribe(callCtx, sub.subscriber, sub.query) //nolint:errcheck
+	eventChan, err := s.client.Subscribe(callCtx, sub.subscriber, sub.query, subscriptionBuffer)
+	cancel()
+	s.lk.Lock()
+	done()
+	if err != nil {
+		return nil, err
+	}
+	// releasing cancels the context and unsubscribes once the call completes, ending the new subscription
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return eventChan, nil
+}
+
+// end forgets the subscription ended by Core and closes the channels of its consumers.
+func (s *subscriptions) end(sub *subscription) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if s.byQuery[sub.query] == sub {
+		delete(s.byQuery, sub.query)
+	}
+	sub.cancel()
+	for _, c := range sub.consumers {
+		close(c.out)
+	}
+}
+
+func (s *subscriptions) consumersOf(sub *subscription) []*consumer {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	consumers := make([]*consumer, 0, len(sub.consumers))
+	for _, c := range sub.consumers {
+		consumers = append(consumers, c)
+	}
+	return consumers
+}
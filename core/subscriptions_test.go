@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestSubscriptions(t *testing.T) {
+	const query = "query"
+
+	eventChan := make(chan ctypes.ResultEvent)
+	client := mocks.NewMockClient(gomock.NewController(t))
+	// consumers share a single subscription to Core, which is released with the last of them
+	client.EXPECT().Subscribe(gomock.Any(), "subscriber", query, gomock.Any()).Return(eventChan, nil).Times(1)
+	unsubscribe := client.EXPECT().Unsubscribe(gomock.Any(), "subscriber", query).Return(nil).Times(1)
+	subs := newSubscriptions(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	first, releaseFirst, err := subs.subscribe(ctx, "subscriber", query)
+	require.NoError(t, err)
+	second, releaseSecond, err := subs.subscribe(ctx, "subscriber", query)
+	require.NoError(t, err)
+
+	eventChan <- ctypes.ResultEvent{Query: query}
+	assert.Equal(t, query, (<-first).Query)
+	assert.Equal(t, query, (<-second).Query)
+
+	require.NoError(t, releaseFirst(ctx))
+	eventChan <- ctypes.ResultEvent{Query: query}
+	assert.Equal(t, query, (<-second).Query)
+
+	require.NoError(t, releaseSecond(ctx))
+	// releasing twice is a no-op
+	require.NoError(t, releaseSecond(ctx))
+
+	// a new consumer makes a new subscription, whose end is propagated to the consumers
+	endedChan := make(chan ctypes.ResultEvent)
+	client.EXPECT().Subscribe(gomock.Any(), "subscriber", query, gomock.Any()).
+		Return(endedChan, nil).Times(1).After(unsubscribe)
+	third, releaseThird, err := subs.subscribe(ctx, "subscriber", query)
+	require.NoError(t, err)
+	close(endedChan)
+	for open := true; open; {
+		_, open = <-third
+	}
+	// Core has ended the subscription already, so there is nothing to unsubscribe from
+	require.NoError(t, releaseThird(ctx))
+}
+
+func TestSubscriptions_HungCore(t *testing.T) {
+	const hung, other = "hung", "other"
+
+	entered := make(chan struct{})
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), hung, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _, _ string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+			close(entered)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Times(1)
+	client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), other, gomock.Any()).
+		Return(make(chan ctypes.ResultEvent), nil).Times(1)
+	client.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), other).Return(nil).Times(1)
+	subs := newSubscriptions(client)
+	subs.timeout = time.Second
+
+	hungErr := make(chan error, 1)
+	go func() {
+		_, _, err := subs.subscribe(context.Background(), "subscriber", hung)
+		hungErr <- err
+	}()
+
+	<-entered
+
+	// a hung call to Core only holds up its own query
+	start := time.Now()
+	_, release, err := subs.subscribe(context.Background(), "subscriber", other)
+	require.NoError(t, err)
+	require.NoError(t, release(context.Background()))
+	assert.Less(t, time.Since(start), subs.timeout/2)
+
+	// and is bounded by the timeout
+	select {
+	case err := <-hungErr:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, IsStreamError(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("hung subscription was not timed out")
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import "time"
+
+const (
+	// resubscribeBackoff is the initial delay between failed attempts to resubscribe to new block events.
+	resubscribeBackoff = time.Second
+	// maxResubscribeBackoff caps the delay between failed attempts to resubscribe to new block events.
+	maxResubscribeBackoff = time.Minute
+)
+
+// watchdog is a timer firing after a period of silence.
+// Zero interval disables the watchdog, so it never fires.
+type watchdog struct {
+	interval time.Duration
+	timer    *time.Timer
+}
+
+func newWatchdog(interval time.Duration) *watchdog {
+	w := &watchdog{interval: interval}
+	if interval > 0 {
+		w.timer = time.NewTimer(interval)
+	}
+	return w
+}
+
+// fired returns a channel signaling that the watchdog fired.
+func (w *watchdog) fired() <-chan time.Time {
+	if w.timer == nil {
+		return nil
+	}
+	return w.timer.C
+}
+
+// reset restarts the period of silence.
+func (w *watchdog) reset() {
+	if w.timer == nil {
+		return
+	}
+	if !w.timer.Stop() {
+		select {
+		case <-w.timer.C:
+		default:
+		}
+	}
+	w.timer.Reset(w.interval)
+}
+
+func (w *watchdog) stop() {
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestBlockFetcher_Watchdog(t *testing.T) {
+	const maxBlockInterval = 50 * time.Millisecond
+
+	var subscriptions, unsubscriptions int32
+	client := mocks.NewMockClient(gomock.NewController(t))
+	client.EXPECT().IsRunning().Return(true).AnyTimes()
+	client.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, string, string, ...int) (<-chan ctypes.ResultEvent, error) {
+			eventChan := make(chan ctypes.ResultEvent, 1)
+			// the first subscription silently dies and never delivers anything
+			if atomic.AddInt32(&subscriptions, 1) > 1 {
+				eventChan <- ctypes.ResultEvent{
+					Data: types.EventDataNewBlock{Block: &types.Block{Header: types.Header{Height: 1}}},
+				}
+			}
+			return eventChan, nil
+		}).AnyTimes()
+	client.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, string, string) error {
+			atomic.AddInt32(&unsubscriptions, 1)
+			return nil
+		}).AnyTimes()
+	fetcher := NewBlockFetcher(client, WithMaxBlockInterval(maxBlockInterval))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	newBlockChan, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	select {
+	case b := <-newBlockChan:
+		assert.EqualValues(t, 1, b.Height)
+	case <-time.After(time.Second):
+		t.Fatal("watchdog did not resubscribe")
+	}
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&subscriptions), int32(2))
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&unsubscriptions), int32(1))
+
+	cancel()
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(context.Background()))
+}
+
+func TestWatchdog_Disabled(t *testing.T) {
+	w := newWatchdog(0)
+	w.reset()
+	assert.Nil(t, w.fired())
+	w.stop()
+}
@@ -20,6 +20,12 @@ type Config struct {
 	Core core.Config
 	P2P  p2p.Config
 	RPC  rpc.Config
+
+	// LogLevel is the minimum level of logs to write, e.g. 'debug' or 'info'.
+	LogLevel string
+	// LogFormat is either 'json' or 'text'.
+	// If both LogLevel and LogFormat are empty, logging is configured from the environment.
+	LogFormat string
 }
 
 // DefaultConfig provides a default Config for a given Node Type 'tp'.
@@ -65,6 +71,12 @@ func LoadConfig(path string) (*Config, error) {
 	return &cfg, cfg.Decode(f)
 }
 
+// Validate checks the Config for values the Node cannot run with.
+func (cfg *Config) Validate() error {
+	_, err := logConfig(cfg)
+	return err
+}
+
 // TODO(@Wondertan): We should have a description for each field written into w,
 //  so users can instantly understand purpose of each field. Ideally, we should have a utility program to parse comments
 //  from actual sources(*.go files) and generate docs from comments. Hint: use 'ast' package.
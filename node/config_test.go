@@ -20,3 +20,17 @@ func TestConfigWriteRead(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, in, &out)
 }
+
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig(Light)
+	require.NoError(t, cfg.Validate())
+
+	cfg.LogLevel, cfg.LogFormat = "debug", LogFormatJSON
+	require.NoError(t, cfg.Validate())
+
+	cfg.LogLevel = "loud"
+	assert.Error(t, cfg.Validate())
+
+	cfg.LogLevel, cfg.LogFormat = "info", "yaml"
+	assert.Error(t, cfg.Validate())
+}
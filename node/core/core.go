@@ -1,26 +1,60 @@
 package core
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/node/fxutil"
 )
 
+var log = logging.Logger("node-core")
+
+const (
+	// DefaultPingTimeout is the default time given to a remote Core endpoint to respond to a ping.
+	DefaultPingTimeout = 5 * time.Second
+	// DefaultHealthCheckInterval is the default interval between health checks of remote Core endpoints.
+	DefaultHealthCheckInterval = 5 * time.Second
+)
+
 // Config combines all configuration fields for managing the relationship with a Core node.
 type Config struct {
 	Remote       bool
 	RemoteConfig struct {
 		Protocol   string
 		RemoteAddr string
+		// FallbackEndpoints are switched to in order if the primary endpoint is unresponsive, until it recovers.
+		FallbackEndpoints []Endpoint
+		// HealthCheckInterval is the interval between health checks of the endpoints when fallback endpoints are
+		// configured. Zero stands for DefaultHealthCheckInterval.
+		HealthCheckInterval time.Duration
+		// PingTimeout limits the time given to a remote Core endpoint to respond to a ping.
+		// Zero stands for DefaultPingTimeout.
+		PingTimeout time.Duration
 	}
+	// DevelopmentMode marks the node as used for development or testing,
+	// so that it is not expected to talk to a public Core node.
+	DevelopmentMode bool
+}
+
+// Endpoint is an address of a remote Core node.
+type Endpoint struct {
+	Protocol   string
+	RemoteAddr string
 }
 
 // DefaultConfig returns default configuration for Core subsystem.
 func DefaultConfig() Config {
-	return Config{
+	cfg := Config{
 		Remote: false,
 	}
+	cfg.RemoteConfig.PingTimeout = DefaultPingTimeout
+	return cfg
 }
 
 // Components collects all the components and services related to managing the relationship with the Core node.
@@ -38,7 +72,99 @@ func Components(cfg Config) fx.Option {
 	)
 }
 
+// Validate checks the Config for misconfigurations.
+// In DevelopmentMode, pointing to a non-loopback remote address is only warned about,
+// as connecting to a public network from a test node is most likely an accident.
+func (cfg Config) Validate() error {
+	if cfg.RemoteConfig.PingTimeout < 0 {
+		return fmt.Errorf("node/core: negative ping timeout %s", cfg.RemoteConfig.PingTimeout)
+	}
+	if cfg.RemoteConfig.HealthCheckInterval < 0 {
+		return fmt.Errorf("node/core: negative health check interval %s", cfg.RemoteConfig.HealthCheckInterval)
+	}
+	for i, endpoint := range cfg.RemoteConfig.FallbackEndpoints {
+		if endpoint.Protocol == "" {
+			return fmt.Errorf("node/core: fallback endpoint %d: empty protocol", i)
+		}
+		if _, _, err := net.SplitHostPort(endpoint.RemoteAddr); err != nil {
+			return fmt.Errorf("node/core: fallback endpoint %d: %w", i, err)
+		}
+	}
+
+	if cfg.Remote && cfg.DevelopmentMode && !isLoopback(cfg.RemoteConfig.RemoteAddr) {
+		log.Warnw("remote Core address is not loopback in development mode",
+			"addr", cfg.RemoteConfig.RemoteAddr)
+	}
+	return nil
+}
+
 // RemoteClient provides a constructor for core.Client over RPC.
+// The remote Core endpoint must respond to a ping, so that the node is never started against an unavailable one.
 func RemoteClient(cfg Config) (core.Client, error) {
-	return core.NewRemote(cfg.RemoteConfig.Protocol, cfg.RemoteConfig.RemoteAddr)
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	primary := Endpoint{Protocol: cfg.RemoteConfig.Protocol, RemoteAddr: cfg.RemoteConfig.RemoteAddr}
+	if len(cfg.RemoteConfig.FallbackEndpoints) == 0 {
+		client, err := dialRemote(connectRemote, primary, cfg.pingTimeout())
+		if err != nil {
+			return nil, fmt.Errorf("node/core: remote Core endpoint %s is unavailable: %w", primary.RemoteAddr, err)
+		}
+		return client, nil
+	}
+	endpoints := append([]Endpoint{primary}, cfg.RemoteConfig.FallbackEndpoints...)
+	return newFailoverClient(endpoints, cfg.pingTimeout(), cfg.healthCheckInterval())
+}
+
+// connectRemote returns a new client to the endpoint.
+func connectRemote(endpoint Endpoint) (core.Client, error) {
+	return core.NewRemote(endpoint.Protocol, endpoint.RemoteAddr)
+}
+
+// dialRemote returns a new client to the endpoint made with connect, if it responds to a ping within the timeout.
+func dialRemote(
+	connect func(Endpoint) (core.Client, error),
+	endpoint Endpoint,
+	pingTimeout time.Duration,
+) (core.Client, error) {
+	client, err := connect(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return client, client.Ping(ctx)
+}
+
+// pingTimeout returns the configured PingTimeout or the default one if it is not set.
+func (cfg Config) pingTimeout() time.Duration {
+	if cfg.RemoteConfig.PingTimeout == 0 {
+		return DefaultPingTimeout
+	}
+	return cfg.RemoteConfig.PingTimeout
+}
+
+// healthCheckInterval returns the configured HealthCheckInterval or the default one if it is not set.
+func (cfg Config) healthCheckInterval() time.Duration {
+	if cfg.RemoteConfig.HealthCheckInterval == 0 {
+		return DefaultHealthCheckInterval
+	}
+	return cfg.RemoteConfig.HealthCheckInterval
+}
+
+// isLoopback reports whether the given 'host:port' address points to the local machine.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
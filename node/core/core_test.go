@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/celestiaorg/celestia-node/core"
+)
+
+func TestConfig_Validate_DevelopmentMode(t *testing.T) {
+	var tests = []struct {
+		addr string
+		dev  bool
+		warn bool
+	}{
+		{addr: "8.8.8.8:26657", dev: true, warn: true},
+		{addr: "node.example.com:26657", dev: true, warn: true},
+		{addr: "127.0.0.1:26657", dev: true, warn: false},
+		{addr: "localhost:26657", dev: true, warn: false},
+		{addr: "[::1]:26657", dev: true, warn: false},
+		{addr: "8.8.8.8:26657", dev: false, warn: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			logs := observeLogs(t)
+
+			cfg := DefaultConfig()
+			cfg.Remote = true
+			cfg.DevelopmentMode = tt.dev
+			cfg.RemoteConfig.Protocol = "tcp"
+			cfg.RemoteConfig.RemoteAddr = tt.addr
+			require.NoError(t, cfg.Validate())
+
+			warns := logs.FilterLevelExact(zap.WarnLevel).Len()
+			if tt.warn {
+				assert.Equal(t, 1, warns)
+			} else {
+				assert.Zero(t, warns)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_FallbackEndpoints(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Remote = true
+	cfg.RemoteConfig.FallbackEndpoints = []Endpoint{{Protocol: "tcp", RemoteAddr: "127.0.0.1:26657"}}
+	require.NoError(t, cfg.Validate())
+
+	noProtocol := Endpoint{RemoteAddr: "127.0.0.1:26657"}
+	cfg.RemoteConfig.FallbackEndpoints = append(cfg.RemoteConfig.FallbackEndpoints, noProtocol)
+	assert.Error(t, cfg.Validate())
+
+	cfg.RemoteConfig.FallbackEndpoints[1] = Endpoint{Protocol: "tcp", RemoteAddr: "127.0.0.1"}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestConfig_Validate_PingTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, DefaultPingTimeout, cfg.pingTimeout())
+	require.NoError(t, cfg.Validate())
+
+	cfg.RemoteConfig.PingTimeout = 0
+	assert.Equal(t, DefaultPingTimeout, cfg.pingTimeout())
+	require.NoError(t, cfg.Validate())
+
+	cfg.RemoteConfig.PingTimeout = -time.Second
+	assert.Error(t, cfg.Validate())
+}
+
+func TestRemoteClient_Ping(t *testing.T) {
+	remote := core.StartMockNode()
+	t.Cleanup(func() {
+		require.NoError(t, remote.Stop())
+	})
+
+	cfg := DefaultConfig()
+	cfg.Remote = true
+	cfg.RemoteConfig.Protocol, cfg.RemoteConfig.RemoteAddr = splitEndpoint(remote.Config().RPC.ListenAddress)
+	_, err := RemoteClient(cfg)
+	require.NoError(t, err)
+
+	// an endpoint which does not respond to a ping is rejected right away
+	cfg.RemoteConfig.Protocol, cfg.RemoteConfig.RemoteAddr = "tcp", "127.0.0.1:1"
+	_, err = RemoteClient(cfg)
+	assert.Error(t, err)
+}
+
+func TestRemoteClient_Failover(t *testing.T) {
+	remote := core.StartMockNode()
+	t.Cleanup(func() {
+		require.NoError(t, remote.Stop())
+	})
+	protocol, addr := splitEndpoint(remote.Config().RPC.ListenAddress)
+
+	cfg := DefaultConfig()
+	cfg.Remote = true
+	// nothing listens on the primary endpoint
+	cfg.RemoteConfig.Protocol, cfg.RemoteConfig.RemoteAddr = "tcp", "127.0.0.1:1"
+	cfg.RemoteConfig.FallbackEndpoints = []Endpoint{
+		{Protocol: "tcp", RemoteAddr: "127.0.0.1:2"},
+		{Protocol: protocol, RemoteAddr: addr},
+	}
+
+	client, err := RemoteClient(cfg)
+	require.NoError(t, err)
+	require.NoError(t, client.Ping(context.Background()))
+
+	cfg.RemoteConfig.FallbackEndpoints = cfg.RemoteConfig.FallbackEndpoints[:1]
+	_, err = RemoteClient(cfg)
+	assert.Error(t, err)
+}
+
+// splitEndpoint splits the 'protocol://addr' Core listen address.
+func splitEndpoint(endpoint string) (string, string) {
+	parts := strings.SplitN(endpoint, "://", 2)
+	return parts[0], parts[1]
+}
+
+// observeLogs redirects logs of the package into the returned observer for the duration of the test.
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zap.DebugLevel)
+	orig := log.SugaredLogger
+	log.SugaredLogger = *zap.New(core).Sugar()
+	t.Cleanup(func() {
+		log.SugaredLogger = orig
+	})
+	return logs
+}
@@ -0,0 +1,441 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-core/libs/bytes"
+	"github.com/celestiaorg/celestia-core/libs/service"
+	"github.com/celestiaorg/celestia-core/rpc/client"
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core"
+)
+
+// failoverClient is a core.Client talking to the most preferred of the remote endpoints which is healthy.
+// Endpoints are health checked with the BlockFetcher heartbeat. Once the active endpoint becomes stale, requests
+// switch to the next healthy endpoint, and switch back once a more preferred endpoint recovers.
+// Subscriptions are moved to the new endpoint on every switch, keeping their channels. An endpoint to which any of
+// the subscriptions cannot be moved is not switched to.
+type failoverClient struct {
+	service.BaseService
+
+	endpoints      []Endpoint
+	connect        func(Endpoint) (core.Client, error)
+	probes         []*core.BlockFetcher
+	pingTimeout    time.Duration
+	healthInterval time.Duration
+	cancel         context.CancelFunc
+
+	// lk guards the active client
+	lk          sync.RWMutex
+	active      core.Client
+	activeIndex int
+	// subsLk guards the subscriptions, so that subscribing never races with switching endpoints
+	subsLk sync.Mutex
+	subs   map[string]*failoverSub
+}
+
+// failoverSub is a subscription kept across endpoints.
+type failoverSub struct {
+	subscriber, query string
+	capacity          []int
+	out               chan ctypes.ResultEvent
+	// source hands the events of the subscription on a new endpoint over, buffering the latest one
+	source chan (<-chan ctypes.ResultEvent)
+	done   chan struct{}
+}
+
+// newFailoverClient makes a client to the first of the given endpoints that responds to a ping.
+func newFailoverClient(endpoints []Endpoint, pingTimeout, healthInterval time.Duration) (*failoverClient, error) {
+	probes := make([]*core.BlockFetcher, len(endpoints))
+	for i, endpoint := range endpoints {
+		// probes are only pinged, which does not need them to be started
+		probe, err := core.NewRemote(endpoint.Protocol, endpoint.RemoteAddr)
+		if err != nil {
+			return nil, err
+		}
+		probes[i] = core.NewBlockFetcher(probe, core.WithPingTimeout(pingTimeout))
+	}
+
+	c := &failoverClient{
+		endpoints:      endpoints,
+		connect:        connectRemote,
+		probes:         probes,
+		pingTimeout:    pingTimeout,
+		healthInterval: healthInterval,
+		subs:           make(map[string]*failoverSub),
+	}
+	c.BaseService = *service.NewBaseService(nil, "FailoverClient", c)
+
+	var errs []string
+	for i, endpoint := range endpoints {
+		active, err := c.dial(i)
+		if err == nil {
+			c.active, c.activeIndex = active, i
+			return c, nil
+		}
+
+		log.Warnw("remote Core endpoint is unavailable", "addr", endpoint.RemoteAddr, "err", err)
+		errs = append(errs, fmt.Sprintf("%s: %s", endpoint.RemoteAddr, err))
+	}
+	return nil, errors.New("node/core: no remote Core endpoint is available: " + strings.Join(errs, "; "))
+}
+
+// dial returns a new client to the endpoint, if it responds to a ping.
+func (c *failoverClient) dial(i int) (core.Client, error) {
+	return dialRemote(c.connect, c.endpoints[i], c.pingTimeout)
+}
+
+func (c *failoverClient) OnStart() error {
+	if err := c.current().Start(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	for _, probe := range c.probes {
+		if err := probe.StartHeartbeat(ctx, c.healthInterval); err != nil {
+			cancel()
+			return err
+		}
+	}
+	go c.pollHealth(ctx)
+	return nil
+}
+
+func (c *failoverClient) OnStop() {
+	c.cancel()
+
+	c.subsLk.Lock()
+	defer c.subsLk.Unlock()
+	for query, sub := range c.subs {
+		close(sub.done)
+		delete(c.subs, query)
+	}
+
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if err := c.active.Stop(); err != nil {
+		log.Errorw("stopping remote Core client", "addr", c.endpoints[c.activeIndex].RemoteAddr, "err", err)
+	}
+}
+
+// pollHealth switches to the most preferred healthy endpoint whenever it is not the active one.
+func (c *failoverClient) pollHealth(ctx context.Context) {
+	ticker := time.NewTicker(c.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.lk.RLock()
+		activeIndex := c.activeIndex
+		c.lk.RUnlock()
+		for i, probe := range c.probes {
+			if i == activeIndex && probe.IsHealthy() {
+				break
+			}
+			if !probe.IsHealthy() {
+				continue
+			}
+
+			err := c.switchTo(ctx, i)
+			if err == nil {
+				break
+			}
+			log.Warnw("remote Core endpoint is unavailable", "addr", c.endpoints[i].RemoteAddr, "err", err)
+		}
+	}
+}
+
+// switchTo makes the endpoint the active one and moves all the subscriptions to it.
+// If any of the subscriptions cannot be moved, nothing is switched and the subscriptions stay where they are.
+func (c *failoverClient) switchTo(ctx context.Context, i int) error {
+	next, err := c.dial(i)
+	if err != nil {
+		return err
+	}
+	err = next.Start()
+	if err != nil {
+		return err
+	}
+
+	c.subsLk.Lock()
+	defer c.subsLk.Unlock()
+
+	// subscriptions are moved before switching, so that requests are never held up by subscribing
+	moved := make(map[*failoverSub]<-chan ctypes.ResultEvent, len(c.subs))
+	for _, sub := range c.subs {
+		subCtx, cancel := context.WithTimeout(ctx, c.pingTimeout)
+		eventChan, err := next.Subscribe(subCtx, sub.subscriber, sub.query, sub.capacity...)
+		cancel()
+		if err != nil {
+			// stopping the client ends the subscriptions moved so far
+			next.Stop() //nolint:errcheck
+			return fmt.Errorf("moving subscription to %s: %w", sub.query, err)
+		}
+		moved[sub] = eventChan
+	}
+
+	c.lk.Lock()
+	log.Warnw("switching remote Core endpoint",
+		"from", c.endpoints[c.activeIndex].RemoteAddr, "to", c.endpoints[i].RemoteAddr)
+	prev := c.active
+	c.active, c.activeIndex = next, i
+	c.lk.Unlock()
+
+	for sub, eventChan := range moved {
+		// replace the events not taken over yet, so that switching never waits for a slow consumer
+		select {
+		case <-sub.source:
+		default:
+		}
+		sub.source <- eventChan
+	}
+
+	// the previous endpoint is most likely unavailable, so its errors are not interesting
+	prev.Stop() //nolint:errcheck
+	return nil
+}
+
+func (c *failoverClient) current() core.Client {
+	c.lk.RLock()
+	defer c.lk.RUnlock()
+	return c.active
+}
+
+// activeEndpoint returns the endpoint requests are currently sent to.
+func (c *failoverClient) activeEndpoint() Endpoint {
+	c.lk.RLock()
+	defer c.lk.RUnlock()
+	return c.endpoints[c.activeIndex]
+}
+
+// Remote returns the address of the endpoint requests are currently sent to.
+func (c *failoverClient) Remote() string {
+	endpoint := c.activeEndpoint()
+	return fmt.Sprintf("%s://%s", endpoint.Protocol, endpoint.RemoteAddr)
+}
+
+func (c *failoverClient) Subscribe(
+	ctx context.Context,
+	subscriber, query string,
+	outCapacity ...int,
+) (<-chan ctypes.ResultEvent, error) {
+	c.subsLk.Lock()
+	defer c.subsLk.Unlock()
+
+	// remote clients key subscriptions by query, so do the failover ones
+	if _, ok := c.subs[query]; ok {
+		return nil, fmt.Errorf("node/core: already subscribed to %s", query)
+	}
+	eventChan, err := c.current().Subscribe(ctx, subscriber, query, outCapacity...)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &failoverSub{
+		subscriber: subscriber,
+		query:      query,
+		capacity:   outCapacity,
+		out:        make(chan ctypes.ResultEvent, cap(eventChan)),
+		source:     make(chan (<-chan ctypes.ResultEvent), 1),
+		done:       make(chan struct{}),
+	}
+	c.subs[query] = sub
+	go sub.forward(eventChan)
+	return sub.out, nil
+}
+
+// forward delivers events of the subscription from whichever endpoint it is on, until it is released.
+func (sub *failoverSub) forward(eventChan <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case <-sub.done:
+			return
+		case eventChan = <-sub.source:
+		case event, ok := <-eventChan:
+			if !ok {
+				// wait for the subscription to be moved to another endpoint
+				eventChan = nil
+				continue
+			}
+			select {
+			case sub.out <- event:
+			case <-sub.done:
+				return
+			}
+		}
+	}
+}
+
+func (c *failoverClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	c.subsLk.Lock()
+	defer c.subsLk.Unlock()
+
+	if sub, ok := c.subs[query]; ok {
+		close(sub.done)
+		delete(c.subs, query)
+	}
+	return c.current().Unsubscribe(ctx, subscriber, query)
+}
+
+func (c *failoverClient) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	c.subsLk.Lock()
+	defer c.subsLk.Unlock()
+
+	for query, sub := range c.subs {
+		close(sub.done)
+		delete(c.subs, query)
+	}
+	return c.current().UnsubscribeAll(ctx, subscriber)
+}
+
+func (c *failoverClient) Ping(ctx context.Context) error {
+	return c.current().Ping(ctx)
+}
+
+func (c *failoverClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	return c.current().ABCIInfo(ctx)
+}
+
+func (c *failoverClient) ABCIQuery(
+	ctx context.Context,
+	path string,
+	data bytes.HexBytes,
+) (*ctypes.ResultABCIQuery, error) {
+	return c.current().ABCIQuery(ctx, path, data)
+}
+
+func (c *failoverClient) ABCIQueryWithOptions(
+	ctx context.Context,
+	path string,
+	data bytes.HexBytes,
+	opts client.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	return c.current().ABCIQueryWithOptions(ctx, path, data, opts)
+}
+
+func (c *failoverClient) BroadcastTxCommit(
+	ctx context.Context,
+	tx types.Tx,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	return c.current().BroadcastTxCommit(ctx, tx)
+}
+
+func (c *failoverClient) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.current().BroadcastTxAsync(ctx, tx)
+}
+
+func (c *failoverClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.current().BroadcastTxSync(ctx, tx)
+}
+
+func (c *failoverClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	return c.current().Block(ctx, height)
+}
+
+func (c *failoverClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
+	return c.current().BlockByHash(ctx, hash)
+}
+
+func (c *failoverClient) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	return c.current().BlockResults(ctx, height)
+}
+
+func (c *failoverClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	return c.current().Commit(ctx, height)
+}
+
+func (c *failoverClient) DataAvailabilityHeader(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultDataAvailabilityHeader, error) {
+	return c.current().DataAvailabilityHeader(ctx, height)
+}
+
+func (c *failoverClient) Validators(
+	ctx context.Context,
+	height *int64,
+	page, perPage *int,
+) (*ctypes.ResultValidators, error) {
+	return c.current().Validators(ctx, height, page, perPage)
+}
+
+func (c *failoverClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	return c.current().Tx(ctx, hash, prove)
+}
+
+func (c *failoverClient) TxSearch(
+	ctx context.Context,
+	query string,
+	prove bool,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearch, error) {
+	return c.current().TxSearch(ctx, query, prove, page, perPage, orderBy)
+}
+
+func (c *failoverClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
+	return c.current().Genesis(ctx)
+}
+
+func (c *failoverClient) BlockchainInfo(
+	ctx context.Context,
+	minHeight, maxHeight int64,
+) (*ctypes.ResultBlockchainInfo, error) {
+	return c.current().BlockchainInfo(ctx, minHeight, maxHeight)
+}
+
+func (c *failoverClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	return c.current().Status(ctx)
+}
+
+func (c *failoverClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
+	return c.current().NetInfo(ctx)
+}
+
+func (c *failoverClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
+	return c.current().DumpConsensusState(ctx)
+}
+
+func (c *failoverClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
+	return c.current().ConsensusState(ctx)
+}
+
+func (c *failoverClient) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+	return c.current().ConsensusParams(ctx, height)
+}
+
+func (c *failoverClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
+	return c.current().Health(ctx)
+}
+
+func (c *failoverClient) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.current().UnconfirmedTxs(ctx, limit)
+}
+
+func (c *failoverClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.current().NumUnconfirmedTxs(ctx)
+}
+
+func (c *failoverClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
+	return c.current().CheckTx(ctx, tx)
+}
+
+func (c *failoverClient) BroadcastEvidence(
+	ctx context.Context,
+	evidence types.Evidence,
+) (*ctypes.ResultBroadcastEvidence, error) {
+	return c.current().BroadcastEvidence(ctx, evidence)
+}
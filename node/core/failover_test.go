@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/celestiaorg/celestia-core/rpc/core/types"
+	"github.com/celestiaorg/celestia-core/types"
+
+	"github.com/celestiaorg/celestia-node/core"
+	"github.com/celestiaorg/celestia-node/core/mocks"
+)
+
+func TestRemoteClient_RuntimeFailover(t *testing.T) {
+	remote := core.StartMockNode()
+	t.Cleanup(func() {
+		require.NoError(t, remote.Stop())
+	})
+	protocol, addr := splitEndpoint(remote.Config().RPC.ListenAddress)
+	primary, fallback := startProxy(t, addr), startProxy(t, addr)
+
+	cfg := DefaultConfig()
+	cfg.Remote = true
+	cfg.RemoteConfig.Protocol, cfg.RemoteConfig.RemoteAddr = protocol, primary.addr
+	cfg.RemoteConfig.FallbackEndpoints = []Endpoint{{Protocol: protocol, RemoteAddr: fallback.addr}}
+	cfg.RemoteConfig.HealthCheckInterval = 200 * time.Millisecond
+
+	client, err := RemoteClient(cfg)
+	require.NoError(t, err)
+	failover := client.(*failoverClient)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		require.NoError(t, client.Stop())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	query := types.QueryForEvent(types.EventNewBlock).String()
+	eventChan, err := client.Subscribe(ctx, "failover", query, 16)
+	require.NoError(t, err)
+	receive := func() {
+		select {
+		case <-eventChan:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no events received")
+		}
+	}
+	receive()
+
+	// the primary goes down, so requests and the subscription move to the fallback
+	primary.down()
+	assert.Eventually(t, func() bool {
+		return failover.activeEndpoint().RemoteAddr == fallback.addr
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, protocol+"://"+fallback.addr, failover.Remote())
+	_, err = client.Status(ctx)
+	require.NoError(t, err)
+	drain(eventChan)
+	receive()
+
+	// the primary recovers, so they move back
+	primary.up()
+	assert.Eventually(t, func() bool {
+		return failover.activeEndpoint().RemoteAddr == primary.addr
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, protocol+"://"+primary.addr, failover.Remote())
+	_, err = client.Status(ctx)
+	require.NoError(t, err)
+	drain(eventChan)
+	receive()
+
+	require.NoError(t, client.Unsubscribe(ctx, "failover", query))
+}
+
+func TestFailoverClient_SwitchTo_SubscriptionFailure(t *testing.T) {
+	remote := core.StartMockNode()
+	t.Cleanup(func() {
+		require.NoError(t, remote.Stop())
+	})
+	protocol, addr := splitEndpoint(remote.Config().RPC.ListenAddress)
+
+	endpoints := []Endpoint{{Protocol: protocol, RemoteAddr: addr}, {Protocol: protocol, RemoteAddr: "127.0.0.1:1"}}
+	// endpoints are only switched manually
+	client, err := newFailoverClient(endpoints, time.Second, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		require.NoError(t, client.Stop())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	query := types.QueryForEvent(types.EventNewBlock).String()
+	eventChan, err := client.Subscribe(ctx, "failover", query, 16)
+	require.NoError(t, err)
+
+	// the subscription cannot be moved to the next endpoint
+	next := mocks.NewMockClient(gomock.NewController(t))
+	next.EXPECT().Ping(gomock.Any()).Return(nil)
+	next.EXPECT().Start().Return(nil)
+	next.EXPECT().Subscribe(gomock.Any(), gomock.Any(), query, gomock.Any()).Return(nil, errors.New("unavailable"))
+	next.EXPECT().Stop().Return(nil)
+	client.connect = func(Endpoint) (core.Client, error) {
+		return next, nil
+	}
+
+	// so the endpoint is not switched to, and the subscription keeps delivering from the active one
+	assert.Error(t, client.switchTo(ctx, 1))
+	assert.Equal(t, endpoints[0], client.activeEndpoint())
+	drain(eventChan)
+	select {
+	case <-eventChan:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no events received")
+	}
+
+	require.NoError(t, client.Unsubscribe(ctx, "failover", query))
+}
+
+// drain discards the events buffered in the channel.
+func drain(eventChan <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case <-eventChan:
+		default:
+			return
+		}
+	}
+}
+
+// proxy forwards TCP connections to the target and can be taken down and up again on the same address.
+type proxy struct {
+	addr, target string
+
+	lk    sync.Mutex
+	ln    net.Listener
+	conns []net.Conn
+}
+
+func startProxy(t *testing.T, target string) *proxy {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	p := &proxy{addr: ln.Addr().String(), target: target, ln: ln}
+	go p.serve(ln)
+	t.Cleanup(p.down)
+	return p
+}
+
+func (p *proxy) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		upstream, err := net.Dial("tcp", p.target)
+		if err != nil {
+			conn.Close()
+			continue
+		}
+
+		p.lk.Lock()
+		p.conns = append(p.conns, conn, upstream)
+		p.lk.Unlock()
+		go io.Copy(upstream, conn) //nolint:errcheck
+		go io.Copy(conn, upstream) //nolint:errcheck
+	}
+}
+
+// down stops accepting connections and breaks the accepted ones.
+func (p *proxy) down() {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	if p.ln != nil {
+		p.ln.Close()
+		p.ln = nil
+	}
+	for _, conn := range p.conns {
+		conn.Close()
+	}
+	p.conns = nil
+}
+
+// up accepts connections again.
+func (p *proxy) up() {
+	ln, err := net.Listen("tcp", p.addr)
+	if err != nil {
+		panic(err)
+	}
+
+	p.lk.Lock()
+	p.ln = ln
+	p.lk.Unlock()
+	go p.serve(ln)
+}
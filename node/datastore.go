@@ -0,0 +1,40 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+)
+
+// NamespacedDatastoreProvider provides a Datastore isolated under the given namespace prefix.
+// Components should get their Datastore from here instead of prefixing keys of the shared one manually, so that
+// keys of different components can never collide.
+// Namespaces must be non-empty and must not contain '/', as a nested namespace would share the keys of its parent.
+type NamespacedDatastoreProvider func(ns string) (datastore.Batching, error)
+
+// NewNamespacedDatastoreProvider creates a NamespacedDatastoreProvider over the given Datastore.
+// Asking for the same namespace multiple times returns the same Datastore.
+func NewNamespacedDatastoreProvider(ds datastore.Batching) NamespacedDatastoreProvider {
+	var (
+		lk     sync.Mutex
+		stores = make(map[string]datastore.Batching)
+	)
+	return func(ns string) (datastore.Batching, error) {
+		if ns == "" || strings.Contains(ns, "/") {
+			return nil, fmt.Errorf("node: invalid datastore namespace '%s'", ns)
+		}
+
+		lk.Lock()
+		defer lk.Unlock()
+
+		store, ok := stores[ns]
+		if !ok {
+			store = namespace.Wrap(ds, datastore.NewKey(ns))
+			stores[ns] = store
+		}
+		return store, nil
+	}
+}
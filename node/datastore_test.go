@@ -0,0 +1,55 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacedDatastoreProvider(t *testing.T) {
+	repo := NewMemRepository()
+	ds, err := repo.Datastore()
+	require.NoError(t, err)
+
+	provide := NewNamespacedDatastoreProvider(ds)
+	headers, err := provide("headers")
+	require.NoError(t, err)
+	samples, err := provide("samples")
+	require.NoError(t, err)
+	same, err := provide("headers")
+	require.NoError(t, err)
+	assert.Same(t, headers, same)
+
+	key := datastore.NewKey("1")
+	err = headers.Put(key, []byte("header"))
+	require.NoError(t, err)
+
+	// the write is visible only within its namespace
+	_, err = samples.Get(key)
+	assert.ErrorIs(t, err, datastore.ErrNotFound)
+	_, err = ds.Get(key)
+	assert.ErrorIs(t, err, datastore.ErrNotFound)
+
+	val, err := headers.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("header"), val)
+
+	val, err = ds.Get(datastore.NewKey("headers").Child(key))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("header"), val)
+}
+
+func TestNamespacedDatastoreProvider_InvalidNamespace(t *testing.T) {
+	repo := NewMemRepository()
+	ds, err := repo.Datastore()
+	require.NoError(t, err)
+
+	provide := NewNamespacedDatastoreProvider(ds)
+	// a nested namespace would overlap with its parent, so that queries on the parent return its keys
+	for _, ns := range []string{"", "/", "headers/index", "/headers"} {
+		_, err = provide(ns)
+		assert.Error(t, err, ns)
+	}
+}
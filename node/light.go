@@ -20,7 +20,9 @@ func lightComponents(cfg *Config, repo Repository) fx.Option {
 			return repo.Config
 		}),
 		fx.Provide(repo.Datastore),
+		fx.Provide(NewNamespacedDatastoreProvider),
 		fx.Provide(repo.Keystore),
+		logComponents(cfg),
 		// components
 		p2p.Components(cfg.P2P),
 	)
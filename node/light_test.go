@@ -1,9 +1,13 @@
 package node
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"os"
 	"testing"
 
+	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -37,3 +41,36 @@ func TestLightLifecycle(t *testing.T) {
 	err = nd.Stop(stopCtx)
 	require.NoError(t, err)
 }
+
+func TestLightJSONLogging(t *testing.T) {
+	out, err := os.CreateTemp(t.TempDir(), "log")
+	require.NoError(t, err)
+	// the logger writes to stderr, so it is swapped before the Node configures logging
+	stderr := os.Stderr
+	os.Stderr = out
+	t.Cleanup(func() {
+		os.Stderr = stderr
+		logging.SetupLogging(logging.Config{Level: logging.LevelError, Stderr: true})
+		out.Close()
+	})
+
+	cfg := DefaultConfig(Light)
+	cfg.LogLevel, cfg.LogFormat = "info", LogFormatJSON
+	nd, err := New(Light, MockRepository(t, cfg))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	require.NoError(t, nd.Start(ctx))
+	require.NoError(t, nd.Stop(ctx))
+
+	_, err = out.Seek(0, 0)
+	require.NoError(t, err)
+	var lines int
+	for scanner := bufio.NewScanner(out); scanner.Scan(); lines++ {
+		entry := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), scanner.Text())
+		assert.Contains(t, entry, "msg")
+	}
+	assert.NotZero(t, lines)
+}
@@ -0,0 +1,55 @@
+package node
+
+import (
+	"fmt"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+)
+
+// Supported values of Config.LogFormat.
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// logComponents configures the module-level logger from the Config.
+// If neither level nor format is set, logging stays configured from the environment.
+func logComponents(cfg *Config) fx.Option {
+	if cfg.LogLevel == "" && cfg.LogFormat == "" {
+		return fx.Options()
+	}
+
+	return fx.Options(
+		fx.Provide(func() (logging.Config, error) {
+			return logConfig(cfg)
+		}),
+		fx.Invoke(logging.SetupLogging),
+	)
+}
+
+// logConfig translates log options of the Config into the logger's one.
+func logConfig(cfg *Config) (logging.Config, error) {
+	lcfg := logging.Config{
+		Format: logging.PlaintextOutput,
+		Level:  logging.LevelInfo,
+		Stderr: true,
+	}
+
+	if cfg.LogLevel != "" {
+		lvl, err := logging.LevelFromString(cfg.LogLevel)
+		if err != nil {
+			return logging.Config{}, fmt.Errorf("node: invalid log level '%s': %w", cfg.LogLevel, err)
+		}
+		lcfg.Level = lvl
+	}
+
+	switch cfg.LogFormat {
+	case "", LogFormatText:
+	case LogFormatJSON:
+		lcfg.Format = logging.JSONOutput
+	default:
+		return logging.Config{}, fmt.Errorf("node: invalid log format '%s'", cfg.LogFormat)
+	}
+	return lcfg, nil
+}
@@ -64,6 +64,10 @@ func New(tp Type, repo Repository) (*Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
 
 	switch tp {
 	case Full: